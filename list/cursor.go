@@ -0,0 +1,136 @@
+package gost
+
+import "errors"
+
+/*
+Cursor walks a NodeList once from front to back, allowing it to be edited along the way. It allows:
+
+- Moving: advancing to the next value of the list.
+
+- Reading/Writing: obtaining or replacing the value at the cursor.
+
+- Inserting: adding values right before or after the cursor.
+
+- Deleting: removing the value at the cursor.
+
+Every operation takes O(1), and the list keeps its size and tail consistent. A Cursor starts before the first value
+of the list, so Next() has to be called to reach it:
+
+	for c := list.Cursor(); c.Next(); {
+		...
+	}
+
+After Delete() the cursor is left in the gap where the value was, and Next() moves on to the value that followed
+it. The list must not be modified other than through the cursor while it is in use.
+*/
+type Cursor struct {
+	list    *NodeList
+	prev    *Node // node preceding the cursor, nil at the front of the list
+	current *Node // node at the cursor, nil if the cursor is in a gap between nodes
+}
+
+// Cursor returns a Cursor positioned before the first value of the list.
+func (list *NodeList) Cursor() *Cursor {
+	return &Cursor{list: list}
+}
+
+// Next advances the cursor to the next value. Returns false once past the last value of the list.
+func (c *Cursor) Next() bool {
+	next := c.following()
+	if next == nil {
+		c.skip()
+		return false
+	}
+	c.skip()
+	c.current = next
+	return true
+}
+
+// Value returns the value at the cursor, or nil if it is not at any value.
+func (c *Cursor) Value() interface{} {
+	if c.current == nil {
+		return nil
+	}
+	return c.current.Data
+}
+
+// Set replaces the value at the cursor with data. Returns an error if the cursor is not at any value.
+func (c *Cursor) Set(data interface{}) error {
+	if c.current == nil {
+		return errors.New("cannot Set() cursor not at a value")
+	}
+	c.current.Data = data
+	return nil
+}
+
+// InsertBefore adds data right before the value at the cursor (or in the gap at the cursor), so Next() skips it.
+func (c *Cursor) InsertBefore(data interface{}) {
+	next := c.current
+	if next == nil {
+		next = c.following()
+	}
+	c.prev = c.link(c.prev, &Node{Data: data, Next: next})
+}
+
+// InsertAfter adds data right after the value at the cursor (or in the gap at the cursor), so Next() visits it.
+func (c *Cursor) InsertAfter(data interface{}) {
+	if c.current == nil {
+		c.link(c.prev, &Node{Data: data, Next: c.following()})
+		return
+	}
+	c.link(c.current, &Node{Data: data, Next: c.current.Next})
+}
+
+// Delete removes the value at the cursor, returning it. Returns an error if the cursor is not at any value.
+func (c *Cursor) Delete() (interface{}, error) {
+	node := c.current
+	if node == nil {
+		return nil, errors.New("cannot Delete() cursor not at a value")
+	}
+	if c.prev == nil {
+		c.list.Head = node.Next
+	} else {
+		c.prev.Next = node.Next
+	}
+	if c.list.Tail == node {
+		c.list.Tail = c.prev
+	}
+	node.Next = nil
+	c.current = nil
+	c.list.size--
+	return node.Data, nil
+}
+
+// Internal function returning the node Next() would move to, or nil at the end of the list.
+func (c *Cursor) following() *Node {
+	switch {
+	case c.current != nil:
+		return c.current.Next
+	case c.prev != nil:
+		return c.prev.Next
+	default:
+		return c.list.Head
+	}
+}
+
+// Internal function which leaves the current node behind, moving the cursor to the gap after it.
+func (c *Cursor) skip() {
+	if c.current != nil {
+		c.prev, c.current = c.current, nil
+	}
+}
+
+// Internal function which links node (whose Next is already set) after prev, or at the front if prev is nil.
+// Returns node.
+func (c *Cursor) link(prev, node *Node) *Node {
+	if prev == nil {
+		c.list.Head = node
+	} else {
+		prev.Next = node
+	}
+	if node.Next == nil {
+		c.list.Tail = node
+	}
+	c.list.size++
+	return node
+}
@@ -0,0 +1,93 @@
+package gost
+
+import "errors"
+
+/*
+Element is an opaque handle to a value stored in a NodeList. Handles are obtained from Front(), Back(), Next() and
+InsertAfter(), and allow walking and editing the list locally in O(1), while the list keeps its size and tail
+consistent.
+
+A handle belongs to the list it was obtained from, and becomes invalid once its value is removed. Moving values
+to another list with SplitAt(), Concat() or Splice() invalidates every handle of the list they leave, including
+those of values which stay in it. Editing operations return an error when given an invalid handle.
+*/
+type Element struct {
+	node       *Node
+	list       *NodeList
+	generation uint64 // generation of the list the handle was issued in
+}
+
+// Value returns the data stored in the Element.
+func (e *Element) Value() interface{} {
+	return e.node.Data
+}
+
+// Front returns a handle to the first element of the list, or nil if empty.
+func (list *NodeList) Front() *Element {
+	if list.size == 0 {
+		return nil
+	}
+	return list.element(list.Head)
+}
+
+// Back returns a handle to the last element of the list, or nil if empty.
+func (list *NodeList) Back() *Element {
+	if list.size == 0 {
+		return nil
+	}
+	return list.element(list.Tail)
+}
+
+// Next returns a handle to the element following e, or nil if e is the last one (or an invalid handle).
+func (list *NodeList) Next(e *Element) *Element {
+	if !list.owns(e) || e.node.Next == nil {
+		return nil
+	}
+	return list.element(e.node.Next)
+}
+
+// InsertAfter adds data right after the element e, returning a handle to it. Returns an error if e is an invalid handle.
+func (list *NodeList) InsertAfter(e *Element, data interface{}) (*Element, error) {
+	if !list.owns(e) {
+		return nil, errors.New("cannot InsertAfter() invalid element")
+	}
+	node := &Node{Data: data, Next: e.node.Next}
+	e.node.Next = node
+	if list.Tail == e.node {
+		list.Tail = node
+	}
+	list.size++
+	return list.element(node), nil
+}
+
+// RemoveAfter deletes the element following e, returning its data.
+// Returns an error if e is an invalid handle or the last element.
+func (list *NodeList) RemoveAfter(e *Element) (interface{}, error) {
+	if !list.owns(e) {
+		return nil, errors.New("cannot RemoveAfter() invalid element")
+	}
+	node := e.node.Next
+	if node == nil {
+		return nil, errors.New("cannot RemoveAfter() last element")
+	}
+	e.node.Next = node.Next
+	node.Next = nil
+	if list.Tail == node {
+		list.Tail = e.node
+	}
+	list.size--
+	return node.Data, nil
+}
+
+// Internal function returning a handle to node, which must belong to the list.
+func (list *NodeList) element(node *Node) *Element {
+	return &Element{node: node, list: list, generation: list.generation}
+}
+
+// Internal function responding whether e is a valid handle of the list. Handles issued before values were moved
+// out of the list are rejected by their generation. Removed nodes are unlinked (their Next is nil), so a node
+// without successor is only valid if it is the tail of the list.
+func (list *NodeList) owns(e *Element) bool {
+	return e != nil && e.list == list && e.generation == list.generation && list.size > 0 &&
+		(e.node.Next != nil || e.node == list.Tail)
+}
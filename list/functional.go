@@ -0,0 +1,112 @@
+package gost
+
+/*
+	The functions defined below consume an Iterator eagerly; LazyMap and LazyFilter are their lazy counterparts.
+	Any NodeList can be passed through its Cursor(), and stacks and queues through their own iterators.
+	Functions comparing values take an equal function, where nil stands for the == operator.
+*/
+
+// Map returns a new NodeList holding mapper applied to every value of it, in order.
+func Map(it Iterator, mapper func(value interface{}) interface{}) *NodeList {
+	return Collect(LazyMap(it, mapper))
+}
+
+// Filter returns a new NodeList holding the values of it satisfying predicate, in order.
+func Filter(it Iterator, predicate func(value interface{}) bool) *NodeList {
+	return Collect(LazyFilter(it, predicate))
+}
+
+// Collect returns a new NodeList holding every value of it, in order.
+func Collect(it Iterator) *NodeList {
+	list := new(NodeList)
+	for it.Next() {
+		list.Append(it.Value())
+	}
+	return list
+}
+
+// Reduce folds the values of it into a single one, starting from initial and combining the accumulated result
+// with each value in turn.
+func Reduce(it Iterator, initial interface{}, reducer func(accumulated, value interface{}) interface{}) interface{} {
+	accumulated := initial
+	for it.Next() {
+		accumulated = reducer(accumulated, it.Value())
+	}
+	return accumulated
+}
+
+// Find returns the first value of it satisfying predicate. Returns false if there is none.
+func Find(it Iterator, predicate func(value interface{}) bool) (interface{}, bool) {
+	for it.Next() {
+		if value := it.Value(); predicate(value) {
+			return value, true
+		}
+	}
+	return nil, false
+}
+
+// IndexOf returns the position of the first value of it equal to target, or -1 if there is none.
+func IndexOf(it Iterator, target interface{}, equal func(a, b interface{}) bool) int {
+	for index := 0; it.Next(); index++ {
+		if equalValues(it.Value(), target, equal) {
+			return index
+		}
+	}
+	return -1
+}
+
+// Contains responds whether any value of it is equal to target.
+func Contains(it Iterator, target interface{}, equal func(a, b interface{}) bool) bool {
+	return IndexOf(it, target, equal) >= 0
+}
+
+// Any responds whether any value of it satisfies predicate. Returns false if it has no values.
+func Any(it Iterator, predicate func(value interface{}) bool) bool {
+	_, found := Find(it, predicate)
+	return found
+}
+
+// All responds whether every value of it satisfies predicate. Returns true if it has no values.
+func All(it Iterator, predicate func(value interface{}) bool) bool {
+	_, found := Find(it, func(value interface{}) bool { return !predicate(value) })
+	return !found
+}
+
+// Partition splits the values of it into two new NodeLists, holding those satisfying predicate and the rest.
+// Both keep the original order.
+func Partition(it Iterator, predicate func(value interface{}) bool) (matching, rest *NodeList) {
+	matching, rest = new(NodeList), new(NodeList)
+	for it.Next() {
+		if value := it.Value(); predicate(value) {
+			matching.Append(value)
+		} else {
+			rest.Append(value)
+		}
+	}
+	return matching, rest
+}
+
+// GroupBy splits the values of it into new NodeLists by the (comparable) key computed by keyOf for each of them.
+// Every group keeps the original order.
+func GroupBy(it Iterator, keyOf func(value interface{}) interface{}) map[interface{}]*NodeList {
+	groups := make(map[interface{}]*NodeList)
+	for it.Next() {
+		value := it.Value()
+		key := keyOf(value)
+		group, ok := groups[key]
+		if !ok {
+			group = new(NodeList)
+			groups[key] = group
+		}
+		group.Append(value)
+	}
+	return groups
+}
+
+// Internal function comparing a and b with equal, or with the == operator if it is nil.
+func equalValues(a, b interface{}, equal func(a, b interface{}) bool) bool {
+	if equal == nil {
+		return a == b
+	}
+	return equal(a, b)
+}
@@ -0,0 +1,159 @@
+package gost
+
+import "errors"
+
+/*
+ImmutableList is a persistent singly linked list. No operation modifies a list; they return a new version of it
+instead, which shares as many Nodes as possible with the original one. It allows:
+
+- Retrieving: obtaining the value contained at any given index within the list.
+
+- Prepending: adding a new value in front of the list in O(1), sharing the whole original list.
+
+- Appending: adding a new value at the last position of the list. As the last Node is shared by every version,
+the whole list has to be copied (O(n)).
+
+- Adding/Setting/Removing: inserting, replacing or deleting the value at any given index. Only the Nodes
+preceding index are copied; the rest of the list is shared.
+
+Since versions are never modified, they can be kept around as snapshots at no cost. A nil *ImmutableList is
+a valid empty list. Its Nodes must not be modified by any other means.
+*/
+type ImmutableList struct {
+	head *Node
+	size int
+}
+
+// NewImmutableList creates an ImmutableList holding items in the given order.
+func NewImmutableList(items ...interface{}) *ImmutableList {
+	var list *ImmutableList
+	for i := len(items) - 1; i >= 0; i-- {
+		list = list.Prepend(items[i])
+	}
+	return list
+}
+
+// Retrieve obtains data stored at position index within the list. Returns the data or an error if out of bounds.
+func (list *ImmutableList) Retrieve(index int) (interface{}, error) {
+	if index < 0 {
+		index += list.Size()
+	}
+	if index >= list.Size() || index < 0 {
+		return nil, errors.New("cannot Retrieve() index out of bounds")
+	}
+	node := list.head
+	for i := 0; i < index; i++ {
+		node = node.Next
+	}
+	return node.Data, nil
+}
+
+// Head returns the first value of the list, or nil if empty.
+func (list *ImmutableList) Head() interface{} {
+	if list.Size() == 0 {
+		return nil
+	}
+	return list.head.Data
+}
+
+// Tail returns the list without its first value, sharing all its Nodes. The tail of an empty list is empty.
+func (list *ImmutableList) Tail() *ImmutableList {
+	if list.Size() <= 1 {
+		return nil
+	}
+	return &ImmutableList{head: list.head.Next, size: list.size - 1}
+}
+
+// Prepend returns a new list with data in front of the current one.
+func (list *ImmutableList) Prepend(data interface{}) *ImmutableList {
+	var head *Node
+	if list != nil {
+		head = list.head
+	}
+	return &ImmutableList{head: &Node{Data: data, Next: head}, size: list.Size() + 1}
+}
+
+// Append returns a new list with data after the last value of the current one.
+func (list *ImmutableList) Append(data interface{}) *ImmutableList {
+	added, _ := list.Add(list.Size(), data)
+	return added
+}
+
+// Add returns a new list with data inserted at position index. Returns an error if out of bounds.
+func (list *ImmutableList) Add(index int, data interface{}) (*ImmutableList, error) {
+	if index < 0 {
+		index += list.Size()
+	}
+	if index > list.Size() || index < 0 {
+		return list, errors.New("cannot Add() index out of bounds")
+	}
+	placeholder, last := list.copyPrefix(index)
+	last.Next = &Node{Data: data, Next: last.Next}
+	return &ImmutableList{head: placeholder.Next, size: list.Size() + 1}, nil
+}
+
+// Set returns a new list with data replacing the value at position index. Returns an error if out of bounds.
+func (list *ImmutableList) Set(index int, data interface{}) (*ImmutableList, error) {
+	if index < 0 {
+		index += list.Size()
+	}
+	if index >= list.Size() || index < 0 {
+		return list, errors.New("cannot Set() index out of bounds")
+	}
+	placeholder, last := list.copyPrefix(index)
+	last.Next = &Node{Data: data, Next: last.Next.Next}
+	return &ImmutableList{head: placeholder.Next, size: list.size}, nil
+}
+
+// Remove returns a new list without the value at position index, along with that value.
+// Returns an error if out of bounds.
+func (list *ImmutableList) Remove(index int) (*ImmutableList, interface{}, error) {
+	if index < 0 {
+		index += list.Size()
+	}
+	if index >= list.Size() || index < 0 {
+		return list, nil, errors.New("cannot Remove() index out of bounds")
+	}
+	placeholder, last := list.copyPrefix(index)
+	data := last.Next.Data
+	last.Next = last.Next.Next
+	if list.size == 1 {
+		return nil, data, nil
+	}
+	return &ImmutableList{head: placeholder.Next, size: list.size - 1}, data, nil
+}
+
+// Values returns the values of the list in order.
+func (list *ImmutableList) Values() []interface{} {
+	values := make([]interface{}, 0, list.Size())
+	if list != nil {
+		for node := list.head; node != nil; node = node.Next {
+			values = append(values, node.Data)
+		}
+	}
+	return values
+}
+
+// Size returns the length of the ImmutableList.
+func (list *ImmutableList) Size() int {
+	if list == nil {
+		return 0
+	}
+	return list.size
+}
+
+// Internal function which copies the first count Nodes of the list after a new placeholder Node. Returns the
+// placeholder, whose Next is the head of the copy, and the last copied Node (or the placeholder itself if count
+// is zero), whose Next is the first shared Node.
+func (list *ImmutableList) copyPrefix(count int) (placeholder, last *Node) {
+	placeholder = new(Node)
+	if list != nil {
+		placeholder.Next = list.head
+	}
+	last = placeholder
+	for i := 0; i < count; i++ {
+		last.Next = &Node{Data: last.Next.Data, Next: last.Next.Next}
+		last = last.Next
+	}
+	return placeholder, last
+}
@@ -0,0 +1,123 @@
+package gost
+
+/*
+Iterator walks a sequence of values once, in order:
+
+	for it := ...; it.Next(); {
+		value := it.Value()
+	}
+
+Next() advances to the next value and reports whether there is one, while Value() returns the value reached.
+Cursor implements Iterator for NodeList; stacks and queues provide iterators of their own. The functional helpers
+(Map, Filter, Reduce...) work on any Iterator.
+
+Modifying the underlying container while iterating it (other than through a Cursor) leads to undefined behaviour.
+*/
+type Iterator interface {
+	Next() bool
+	Value() interface{}
+}
+
+// NewNodeIterator returns an Iterator over the chain of Nodes starting at head.
+func NewNodeIterator(head *Node) Iterator {
+	return &nodeIterator{next: head}
+}
+
+// NewSliceIterator returns an Iterator over values, from first to last.
+func NewSliceIterator(values []interface{}) Iterator {
+	return &sliceIterator{values: values, index: -1}
+}
+
+// LazyMap returns an Iterator yielding mapper applied to every value of it, computed as they are reached.
+func LazyMap(it Iterator, mapper func(value interface{}) interface{}) Iterator {
+	return &mapIterator{source: it, mapper: mapper}
+}
+
+// LazyFilter returns an Iterator yielding the values of it satisfying predicate, checked as they are reached.
+func LazyFilter(it Iterator, predicate func(value interface{}) bool) Iterator {
+	return &filterIterator{source: it, predicate: predicate}
+}
+
+// nodeIterator walks a chain of Nodes.
+type nodeIterator struct {
+	current *Node
+	next    *Node
+}
+
+func (it *nodeIterator) Next() bool {
+	it.current = it.next
+	if it.current == nil {
+		return false
+	}
+	it.next = it.current.Next
+	return true
+}
+
+func (it *nodeIterator) Value() interface{} {
+	if it.current == nil {
+		return nil
+	}
+	return it.current.Data
+}
+
+// sliceIterator walks a slice from first to last.
+type sliceIterator struct {
+	values []interface{}
+	index  int
+}
+
+func (it *sliceIterator) Next() bool {
+	if it.index < len(it.values) {
+		it.index++
+	}
+	return it.index < len(it.values)
+}
+
+func (it *sliceIterator) Value() interface{} {
+	if it.index < 0 || it.index >= len(it.values) {
+		return nil
+	}
+	return it.values[it.index]
+}
+
+// mapIterator applies a mapper to the values of another Iterator.
+type mapIterator struct {
+	source Iterator
+	mapper func(value interface{}) interface{}
+	value  interface{}
+}
+
+func (it *mapIterator) Next() bool {
+	if !it.source.Next() {
+		it.value = nil
+		return false
+	}
+	it.value = it.mapper(it.source.Value())
+	return true
+}
+
+func (it *mapIterator) Value() interface{} {
+	return it.value
+}
+
+// filterIterator skips the values of another Iterator not satisfying a predicate.
+type filterIterator struct {
+	source    Iterator
+	predicate func(value interface{}) bool
+	value     interface{}
+}
+
+func (it *filterIterator) Next() bool {
+	for it.source.Next() {
+		if value := it.source.Value(); it.predicate(value) {
+			it.value = value
+			return true
+		}
+	}
+	it.value = nil
+	return false
+}
+
+func (it *filterIterator) Value() interface{} {
+	return it.value
+}
@@ -9,6 +9,7 @@ import (
 )
 
 // Basic Node struct, basis of any single-linked list structure.
+// Nodes owned by a NodeList must not be relinked directly, as that corrupts the list; use Element handles instead.
 type Node struct {
 	Data interface{}
 	Next *Node
@@ -26,12 +27,17 @@ allows:
 
 - Removing: deleting a value from the list, obtaining it if needed.
 
+- Editing locally: walking the list through Element handles, inserting and removing values next to them in O(1).
+
 Note that the implementation is NOT thread-safe.
 */
 type NodeList struct {
+	// Deprecated: relinking nodes from outside the list corrupts it. Use Front() and Element handles instead.
 	Head *Node
-	Tail *Node
-	size int
+	// Deprecated: relinking nodes from outside the list corrupts it. Use Back() and Element handles instead.
+	Tail       *Node
+	size       int
+	generation uint64 // increased when values are moved out, invalidating the Element handles issued before
 }
 
 // Internal function used to iterate through the list and retrieve a Node at the index value. Doesn't check for errors.
@@ -115,7 +121,10 @@ func (list *NodeList) Remove(index int) (interface{}, error) {
 		return nil, errors.New("cannot Remove() index out of bounds")
 	}
 	var data interface{}
-	if index == int(list.size)-1 {
+	if list.size == 1 { // the only Node is both Head and Tail; neither may keep pointing to it
+		data = list.Head.Data
+		list.Head, list.Tail = nil, nil
+	} else if index == int(list.size)-1 {
 		data = list.Tail.Data
 		prev := list.getNode(int(list.size) - 2)
 		prev.Next = nil
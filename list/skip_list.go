@@ -0,0 +1,287 @@
+package gost
+
+import (
+	"errors"
+	"math/rand"
+)
+
+// skipListMaxLevel bounds the height of skip list nodes; with p = 1/4 it suffices for 4^32 keys.
+const skipListMaxLevel = 32
+
+/*
+SkipList is an ordered map from keys of type K to values of type V, backed by an indexable skip list. Keys are
+ordered by a compare function returning a negative number, zero or a positive number when a is respectively lower
+than, equal to or greater than b. It allows:
+
+- Putting/Getting/Deleting: storing, retrieving and removing the value of a key in expected O(log n).
+
+- Searching by order: obtaining the entry with the greatest key lower or equal to a given one (Floor), or with the
+lowest key greater or equal to it (Ceiling), in expected O(log n).
+
+- Ranking: obtaining how many keys are lower than a given one in expected O(log n), as every link keeps track of
+the amount of entries it skips over.
+
+- Scanning: iterating over the entries within a range of keys in ascending order.
+
+Node heights are drawn from a random source seeded on creation, so a given seed and sequence of operations
+always yields the same structure.
+
+Note that the implementation is NOT thread-safe.
+*/
+type SkipList[K, V any] struct {
+	compare func(a, b K) int
+	head    *skipNode[K, V]
+	level   int // amount of levels in use
+	size    int
+	random  *rand.Rand
+}
+
+// skipNode holds an entry of a SkipList, linked to the following node at each of its levels.
+type skipNode[K, V any] struct {
+	key   K
+	value V
+	next  []*skipNode[K, V]
+	span  []int // span[i] is the amount of level 0 steps covered by next[i] (up to the end of the list if nil)
+}
+
+// NewSkipList creates an empty SkipList ordering keys by compare, with node heights drawn from a random source
+// initialized with seed.
+func NewSkipList[K, V any](compare func(a, b K) int, seed int64) *SkipList[K, V] {
+	return &SkipList[K, V]{
+		compare: compare,
+		head:    &skipNode[K, V]{next: make([]*skipNode[K, V], skipListMaxLevel), span: make([]int, skipListMaxLevel)},
+		level:   1,
+		random:  rand.New(rand.NewSource(seed)),
+	}
+}
+
+// Put stores value under key, replacing the previous value of key if already present.
+func (sl *SkipList[K, V]) Put(key K, value V) {
+	var update [skipListMaxLevel]*skipNode[K, V]
+	var rank [skipListMaxLevel]int
+	node := sl.head
+	for i := sl.level - 1; i >= 0; i-- {
+		if i < sl.level-1 {
+			rank[i] = rank[i+1]
+		}
+		for node.next[i] != nil && sl.compare(node.next[i].key, key) < 0 {
+			rank[i] += node.span[i]
+			node = node.next[i]
+		}
+		update[i] = node
+	}
+	if next := node.next[0]; next != nil && sl.compare(next.key, key) == 0 {
+		next.value = value
+		return
+	}
+	level := sl.randomLevel()
+	for i := sl.level; i < level; i++ {
+		rank[i], update[i] = 0, sl.head
+		sl.head.span[i] = sl.size
+	}
+	if level > sl.level {
+		sl.level = level
+	}
+	node = &skipNode[K, V]{key: key, value: value, next: make([]*skipNode[K, V], level), span: make([]int, level)}
+	for i := 0; i < level; i++ {
+		node.next[i], update[i].next[i] = update[i].next[i], node
+		node.span[i] = update[i].span[i] - (rank[0] - rank[i])
+		update[i].span[i] = rank[0] - rank[i] + 1
+	}
+	for i := level; i < sl.level; i++ {
+		update[i].span[i]++
+	}
+	sl.size++
+}
+
+// Get obtains the value stored under key. Returns the value or an error (and the zero V) if key is not present.
+func (sl *SkipList[K, V]) Get(key K) (V, error) {
+	node := sl.lowerNode(key).next[0]
+	if node == nil || sl.compare(node.key, key) != 0 {
+		var zero V
+		return zero, errors.New("cannot Get() key not found")
+	}
+	return node.value, nil
+}
+
+// Contains responds whether key is present.
+func (sl *SkipList[K, V]) Contains(key K) bool {
+	_, err := sl.Get(key)
+	return err == nil
+}
+
+// Delete removes key, returning its value. Returns an error (and the zero V) if key is not present.
+func (sl *SkipList[K, V]) Delete(key K) (V, error) {
+	var update [skipListMaxLevel]*skipNode[K, V]
+	node := sl.head
+	for i := sl.level - 1; i >= 0; i-- {
+		for node.next[i] != nil && sl.compare(node.next[i].key, key) < 0 {
+			node = node.next[i]
+		}
+		update[i] = node
+	}
+	node = node.next[0]
+	if node == nil || sl.compare(node.key, key) != 0 {
+		var zero V
+		return zero, errors.New("cannot Delete() key not found")
+	}
+	for i := 0; i < sl.level; i++ {
+		if update[i].next[i] == node {
+			update[i].span[i] += node.span[i] - 1
+			update[i].next[i] = node.next[i]
+		} else {
+			update[i].span[i]--
+		}
+	}
+	for sl.level > 1 && sl.head.next[sl.level-1] == nil {
+		sl.head.span[sl.level-1] = 0
+		sl.level--
+	}
+	sl.size--
+	return node.value, nil
+}
+
+// Floor obtains the entry with the greatest key lower or equal to key. Returns an error (and zero values) if there
+// is none.
+func (sl *SkipList[K, V]) Floor(key K) (K, V, error) {
+	node := sl.head
+	for i := sl.level - 1; i >= 0; i-- {
+		for node.next[i] != nil && sl.compare(node.next[i].key, key) <= 0 {
+			node = node.next[i]
+		}
+	}
+	if node == sl.head {
+		var zeroKey K
+		var zeroValue V
+		return zeroKey, zeroValue, errors.New("cannot Floor() no lower or equal key")
+	}
+	return node.key, node.value, nil
+}
+
+// Ceiling obtains the entry with the lowest key greater or equal to key. Returns an error (and zero values) if
+// there is none.
+func (sl *SkipList[K, V]) Ceiling(key K) (K, V, error) {
+	node := sl.lowerNode(key).next[0]
+	if node == nil {
+		var zeroKey K
+		var zeroValue V
+		return zeroKey, zeroValue, errors.New("cannot Ceiling() no greater or equal key")
+	}
+	return node.key, node.value, nil
+}
+
+// Rank returns the amount of keys lower than key, which is the position key has (or would have) in ascending order.
+func (sl *SkipList[K, V]) Rank(key K) int {
+	rank := 0
+	node := sl.head
+	for i := sl.level - 1; i >= 0; i-- {
+		for node.next[i] != nil && sl.compare(node.next[i].key, key) < 0 {
+			rank += node.span[i]
+			node = node.next[i]
+		}
+	}
+	return rank
+}
+
+// Range returns an iterator over the entries with keys in [from, to), in ascending order.
+func (sl *SkipList[K, V]) Range(from, to K) *SkipListIterator[K, V] {
+	return &SkipListIterator[K, V]{list: sl, next: sl.lowerNode(from).next[0], to: to, bounded: true}
+}
+
+// RangeFrom returns an iterator over the entries with keys greater or equal to from, in ascending order.
+func (sl *SkipList[K, V]) RangeFrom(from K) *SkipListIterator[K, V] {
+	return &SkipListIterator[K, V]{list: sl, next: sl.lowerNode(from).next[0]}
+}
+
+// RangeTo returns an iterator over the entries with keys lower than to, in ascending order.
+func (sl *SkipList[K, V]) RangeTo(to K) *SkipListIterator[K, V] {
+	return &SkipListIterator[K, V]{list: sl, next: sl.head.next[0], to: to, bounded: true}
+}
+
+// Iterator returns an iterator over every entry, in ascending order of keys.
+func (sl *SkipList[K, V]) Iterator() *SkipListIterator[K, V] {
+	return &SkipListIterator[K, V]{list: sl, next: sl.head.next[0]}
+}
+
+// Size returns the amount of keys in the SkipList.
+func (sl *SkipList[K, V]) Size() int {
+	return sl.size
+}
+
+// Internal function returning the last node with a key lower than key (the head if there is none).
+func (sl *SkipList[K, V]) lowerNode(key K) *skipNode[K, V] {
+	node := sl.head
+	for i := sl.level - 1; i >= 0; i-- {
+		for node.next[i] != nil && sl.compare(node.next[i].key, key) < 0 {
+			node = node.next[i]
+		}
+	}
+	return node
+}
+
+// Internal function drawing the height of a new node: each additional level is reached with probability 1/4.
+func (sl *SkipList[K, V]) randomLevel() int {
+	level := 1
+	for level < skipListMaxLevel && sl.random.Int63()&3 == 0 {
+		level++
+	}
+	return level
+}
+
+/*
+SkipListIterator walks a range of entries of a SkipList in ascending order of keys. Values() adapts it to Iterator,
+so it can be used with the functional helpers. The SkipList must not be modified while iterating it.
+*/
+type SkipListIterator[K, V any] struct {
+	list    *SkipList[K, V]
+	current *skipNode[K, V]
+	next    *skipNode[K, V]
+	to      K
+	bounded bool // whether the range stops before to
+}
+
+// Next advances to the next entry of the range. Returns false once past the last one.
+func (it *SkipListIterator[K, V]) Next() bool {
+	it.current = it.next
+	if it.current != nil && it.bounded && it.list.compare(it.current.key, it.to) >= 0 {
+		it.current = nil
+	}
+	if it.current == nil {
+		it.next = nil
+		return false
+	}
+	it.next = it.current.next[0]
+	return true
+}
+
+// Key returns the key of the entry reached, or the zero K if none.
+func (it *SkipListIterator[K, V]) Key() K {
+	if it.current == nil {
+		var zero K
+		return zero
+	}
+	return it.current.key
+}
+
+// Value returns the value of the entry reached, or the zero V if none.
+func (it *SkipListIterator[K, V]) Value() V {
+	if it.current == nil {
+		var zero V
+		return zero
+	}
+	return it.current.value
+}
+
+// Values returns an Iterator yielding the values of the remaining entries of the range.
+func (it *SkipListIterator[K, V]) Values() Iterator {
+	return skipListValues[K, V]{it}
+}
+
+// skipListValues adapts a SkipListIterator to Iterator.
+type skipListValues[K, V any] struct {
+	*SkipListIterator[K, V]
+}
+
+func (values skipListValues[K, V]) Value() interface{} {
+	return values.SkipListIterator.Value()
+}
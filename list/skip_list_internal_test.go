@@ -0,0 +1,60 @@
+package gost
+
+import (
+	"math/rand"
+	"testing"
+)
+
+/*
+	SkipList promises that a given seed and sequence of operations always yields the same structure. Node heights
+	are not observable through its API, so the test below lives in the package itself, rather than in the test
+	directory, to compare them directly.
+*/
+
+// Internal function returning the height of every node of sl, in ascending order of keys.
+func skipListHeights(sl *SkipList[int, int]) []int {
+	var heights []int
+	for node := sl.head.next[0]; node != nil; node = node.next[0] {
+		heights = append(heights, len(node.next))
+	}
+	return heights
+}
+
+// Internal function building a SkipList with seed through a fixed sequence of puts and deletes.
+func generateSeededSkipList(seed int64) *SkipList[int, int] {
+	sl := NewSkipList[int, int](func(a, b int) int { return a - b }, seed)
+	random := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		key := random.Intn(500)
+		if i%3 == 2 {
+			sl.Delete(key)
+		} else {
+			sl.Put(key, i)
+		}
+	}
+	return sl
+}
+
+func TestSkipList_Seed(t *testing.T) {
+	first, second, other := generateSeededSkipList(7), generateSeededSkipList(7), generateSeededSkipList(8)
+	if first.level != second.level {
+		t.Fatalf("NewSkipList() error; expected same level for the same seed, got: %v and %v", first.level, second.level)
+	}
+	heights, expected := skipListHeights(second), skipListHeights(first)
+	if len(heights) != len(expected) {
+		t.Fatalf("NewSkipList() error; expected %v nodes for the same seed, got: %v", len(expected), len(heights))
+	}
+	for i := range expected {
+		if heights[i] != expected[i] {
+			t.Fatalf("NewSkipList() error; expected height %v at node %v for the same seed, got: %v", expected[i], i, heights[i])
+		}
+	}
+	// Guard against heights not depending on the seed at all.
+	same := true
+	for i, height := range skipListHeights(other) {
+		same = same && height == expected[i]
+	}
+	if same {
+		t.Error("NewSkipList() error; expected different seeds to yield different structures")
+	}
+}
@@ -0,0 +1,99 @@
+package gost
+
+/*
+	The methods defined below sort a NodeList in place with a bottom-up merge sort, relinking its Nodes rather than
+	moving their data: sorting takes O(n log n) time and O(1) extra space, and Element handles remain valid.
+	Values are compared with a less function, which reports whether a must be placed before b.
+*/
+
+// Sort orders the list by less. The sort is stable, so it is equivalent to SortStable.
+func (list *NodeList) Sort(less func(a, b interface{}) bool) {
+	list.SortStable(less)
+}
+
+// SortStable orders the list by less, keeping the original order of equal values.
+func (list *NodeList) SortStable(less func(a, b interface{}) bool) {
+	if list.size < 2 {
+		return
+	}
+	placeholder := &Node{Next: list.Head}
+	var last *Node
+	for width := 1; width < list.size; width *= 2 {
+		remaining := placeholder.Next
+		last = placeholder
+		for remaining != nil {
+			left := remaining
+			right := splitAfter(left, width)
+			remaining = splitAfter(right, width)
+			last = mergeRuns(left, right, last, less)
+		}
+	}
+	list.Head, list.Tail = placeholder.Next, last
+}
+
+// IsSorted responds whether the list is ordered by less.
+func (list *NodeList) IsSorted(less func(a, b interface{}) bool) bool {
+	if list.size < 2 {
+		return true
+	}
+	for node := list.Head; node.Next != nil; node = node.Next {
+		if less(node.Next.Data, node.Data) {
+			return false
+		}
+	}
+	return true
+}
+
+// InsertSorted adds data into a list ordered by less, after any values equal to it, so the list remains sorted.
+func (list *NodeList) InsertSorted(data interface{}, less func(a, b interface{}) bool) {
+	if list.size == 0 || less(data, list.Head.Data) {
+		list.Add(0, data)
+		return
+	}
+	prev := list.Head
+	for prev.Next != nil && !less(data, prev.Next.Data) {
+		prev = prev.Next
+	}
+	node := &Node{Data: data, Next: prev.Next}
+	prev.Next = node
+	if list.Tail == prev {
+		list.Tail = node
+	}
+	list.size++
+}
+
+// Internal function which cuts the chain of Nodes starting at node after count of them. Returns the rest of the
+// chain, or nil if it held no more than count Nodes.
+func splitAfter(node *Node, count int) *Node {
+	for i := 1; node != nil && i < count; i++ {
+		node = node.Next
+	}
+	if node == nil {
+		return nil
+	}
+	rest := node.Next
+	node.Next = nil
+	return rest
+}
+
+// Internal function which merges the sorted chains left and right after last, taking from left on ties.
+// Returns the last Node of the merged chain.
+func mergeRuns(left, right, last *Node, less func(a, b interface{}) bool) *Node {
+	for left != nil && right != nil {
+		if less(right.Data, left.Data) {
+			last.Next, right = right, right.Next
+		} else {
+			last.Next, left = left, left.Next
+		}
+		last = last.Next
+	}
+	if left != nil {
+		last.Next = left
+	} else {
+		last.Next = right
+	}
+	for last.Next != nil {
+		last = last.Next
+	}
+	return last
+}
@@ -0,0 +1,117 @@
+package gost
+
+import "errors"
+
+/*
+	The methods defined below relink whole runs of Nodes at once, rather than appending values one by one.
+	Concat() and Splice() take ownership of the Nodes of the other list, leaving it empty, while SplitAt() hands
+	the Nodes after the split point over to a new list. Element handles of a list losing values this way become
+	invalid, as do Cursors of values moved to another list. Handles of a list gaining values, or whose values are
+	only reordered by Reverse() and Rotate(), stay valid.
+*/
+
+// Concat moves every value of other to the end of the list in O(1), leaving other empty.
+// Returns an error if other is the list itself.
+func (list *NodeList) Concat(other *NodeList) error {
+	if other == list {
+		return errors.New("cannot Concat() list with itself")
+	}
+	return list.Splice(list.size, other)
+}
+
+// Splice moves every value of other into the list, starting at position index, leaving other empty.
+// Takes O(index), or O(1) when index is the size of the list.
+// Returns an error if other is the list itself or index is out of bounds.
+func (list *NodeList) Splice(index int, other *NodeList) error {
+	if other == list {
+		return errors.New("cannot Splice() list with itself")
+	}
+	isReverse, value := list.reverseIndex(index)
+	if isReverse {
+		index = value
+	}
+	if index > list.size || index < 0 {
+		return errors.New("cannot Splice() index out of bounds")
+	}
+	if other.size == 0 {
+		return nil
+	}
+	if index == 0 {
+		other.Tail.Next = list.Head
+		list.Head = other.Head
+	} else {
+		prev := list.Tail // appending after the tail takes O(1)
+		if index < list.size {
+			prev = list.getNode(index - 1)
+		}
+		other.Tail.Next = prev.Next
+		prev.Next = other.Head
+	}
+	if index == list.size {
+		list.Tail = other.Tail
+	}
+	list.size += other.size
+	other.Head, other.Tail, other.size = nil, nil, 0
+	other.generation++
+	return nil
+}
+
+// SplitAt cuts the list at position index: the list keeps the values before it, and the rest are moved to a new
+// list, which is returned. Takes O(index). Returns an error if index is out of bounds.
+func (list *NodeList) SplitAt(index int) (*NodeList, error) {
+	isReverse, value := list.reverseIndex(index)
+	if isReverse {
+		index = value
+	}
+	if index > list.size || index < 0 {
+		return nil, errors.New("cannot SplitAt() index out of bounds")
+	}
+	rest := new(NodeList)
+	if index == list.size {
+		return rest, nil
+	}
+	rest.Tail, rest.size = list.Tail, list.size-index
+	if index == 0 {
+		rest.Head = list.Head
+		list.Head, list.Tail = nil, nil
+	} else {
+		prev := list.getNode(index - 1)
+		rest.Head = prev.Next
+		prev.Next = nil
+		list.Tail = prev
+	}
+	list.size = index
+	list.generation++
+	return rest, nil
+}
+
+// Reverse reverses the order of the values of the list in place.
+func (list *NodeList) Reverse() {
+	var prev *Node
+	for node := list.Head; node != nil; {
+		next := node.Next
+		node.Next = prev
+		prev, node = node, next
+	}
+	list.Head, list.Tail = list.Tail, list.Head
+}
+
+// Rotate moves the first k values of the list to its end in place, keeping their order. Negative k rotates
+// the other way round, moving the last -k values to the front. k may exceed the size of the list.
+func (list *NodeList) Rotate(k int) {
+	if list.size < 2 {
+		return
+	}
+	k %= list.size
+	if k < 0 {
+		k += list.size
+	}
+	if k == 0 {
+		return
+	}
+	newTail := list.getNode(k - 1)
+	list.Tail.Next = list.Head
+	list.Head = newTail.Next
+	newTail.Next = nil
+	list.Tail = newTail
+}
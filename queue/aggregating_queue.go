@@ -0,0 +1,191 @@
+package gost
+
+import (
+	"github.com/christat/gost/stack"
+)
+
+/*
+AggregatingQueue is a FIFO queue backed by two SliceStacks (the "two-stack trick"). Besides the usual
+queue operations it keeps a running aggregate of its contents, so that a sliding-window reduction
+(sum, max, min...) can be queried without walking the queue. It allows:
+
+- Enqueuing: inserting an item into the last position of the queue in O(1).
+
+- De-queuing: retrieving the first item in the queue in amortized O(1) (see DABAQueue for worst-case O(1)).
+
+- Aggregating: obtaining combine(x1, combine(x2, ... xn)) over the queue contents in O(1).
+
+The combine function must be associative, but it is not required to be commutative;
+items are always combined in queue order (oldest first).
+
+Note that the implementation is NOT thread-safe.
+*/
+type AggregatingQueue struct {
+	front   *gost.SliceStack // items ready to be dequeued; top of the stack is the oldest item
+	back    *gost.SliceStack // newly enqueued items; top of the stack is the newest item
+	combine func(a, b interface{}) interface{}
+}
+
+// aggregateEntry wraps a value with the aggregate of the stack section it closes.
+type aggregateEntry struct {
+	value     interface{}
+	aggregate interface{}
+}
+
+// NewAggregatingQueue creates an empty AggregatingQueue which aggregates its contents using combine.
+func NewAggregatingQueue(combine func(a, b interface{}) interface{}) *AggregatingQueue {
+	return &AggregatingQueue{front: gost.NewStack(10), back: gost.NewStack(10), combine: combine}
+}
+
+// Enqueue adds data (interface{}) to the tail of the queue.
+func (queue *AggregatingQueue) Enqueue(data interface{}) {
+	queue.back.Push(pushBack(queue.back, data, queue.combine))
+}
+
+// Dequeue removes the head of the queue. Returns the data or nil if empty.
+func (queue *AggregatingQueue) Dequeue() interface{} {
+	if queue.front.Size() == 0 {
+		// Flip the back stack onto the front one, recomputing aggregates from the newest item backwards.
+		for queue.back.Size() > 0 {
+			entry := queue.back.Pop().(aggregateEntry)
+			queue.front.Push(pushFront(queue.front, entry.value, queue.combine))
+		}
+	}
+	if queue.front.Size() > 0 {
+		return queue.front.Pop().(aggregateEntry).value
+	}
+	return nil
+}
+
+// Aggregate returns the combination of every item in the queue, in queue order. Returns nil if empty.
+func (queue *AggregatingQueue) Aggregate() interface{} {
+	return aggregateOf(queue.front, queue.back, queue.combine)
+}
+
+// Size returns the length of the AggregatingQueue.
+func (queue *AggregatingQueue) Size() int {
+	return queue.front.Size() + queue.back.Size()
+}
+
+/*
+AggregatingDeque is the double-ended version of AggregatingQueue. Items can be inserted and removed at
+both ends in amortized O(1); whenever one of its stacks runs dry, the other one is split in half so
+that neither end degrades to linear time. The pop triggering a split takes O(n) though; FIFO workloads
+which cannot afford such spikes should use DABAQueue instead, whose operations are worst-case O(1). It allows:
+
+- Pushing: inserting an item at the front or the back of the deque.
+
+- Popping: retrieving the item at the front or the back of the deque.
+
+- Aggregating: obtaining combine(x1, combine(x2, ... xn)) over the deque contents in O(1).
+
+Note that the implementation is NOT thread-safe.
+*/
+type AggregatingDeque struct {
+	front   *gost.SliceStack // top of the stack is the front item
+	back    *gost.SliceStack // top of the stack is the back item
+	combine func(a, b interface{}) interface{}
+}
+
+// NewAggregatingDeque creates an empty AggregatingDeque which aggregates its contents using combine.
+func NewAggregatingDeque(combine func(a, b interface{}) interface{}) *AggregatingDeque {
+	return &AggregatingDeque{front: gost.NewStack(10), back: gost.NewStack(10), combine: combine}
+}
+
+// PushFront adds data (interface{}) to the front of the deque.
+func (deque *AggregatingDeque) PushFront(data interface{}) {
+	deque.front.Push(pushFront(deque.front, data, deque.combine))
+}
+
+// PushBack adds data (interface{}) to the back of the deque.
+func (deque *AggregatingDeque) PushBack(data interface{}) {
+	deque.back.Push(pushBack(deque.back, data, deque.combine))
+}
+
+// PopFront removes the item at the front of the deque. Returns the data or nil if empty.
+func (deque *AggregatingDeque) PopFront() interface{} {
+	if deque.front.Size() == 0 {
+		deque.rebalance(deque.back)
+	}
+	if deque.front.Size() > 0 {
+		return deque.front.Pop().(aggregateEntry).value
+	}
+	return nil
+}
+
+// PopBack removes the item at the back of the deque. Returns the data or nil if empty.
+func (deque *AggregatingDeque) PopBack() interface{} {
+	if deque.back.Size() == 0 {
+		deque.rebalance(deque.front)
+	}
+	if deque.back.Size() > 0 {
+		return deque.back.Pop().(aggregateEntry).value
+	}
+	return nil
+}
+
+// Aggregate returns the combination of every item in the deque, from front to back. Returns nil if empty.
+func (deque *AggregatingDeque) Aggregate() interface{} {
+	return aggregateOf(deque.front, deque.back, deque.combine)
+}
+
+// Size returns the length of the AggregatingDeque.
+func (deque *AggregatingDeque) Size() int {
+	return deque.front.Size() + deque.back.Size()
+}
+
+// Internal function which redistributes the contents of the non-empty stack source evenly between both stacks.
+func (deque *AggregatingDeque) rebalance(source *gost.SliceStack) {
+	// Drain source; values ends up ordered from the top of source to its bottom.
+	values := make([]interface{}, 0, source.Size())
+	for source.Size() > 0 {
+		values = append(values, source.Pop().(aggregateEntry).value)
+	}
+	if source == deque.back {
+		// values run from back to front; flip them so that index 0 is the front item.
+		for i, j := 0, len(values)-1; i < j; i, j = i+1, j-1 {
+			values[i], values[j] = values[j], values[i]
+		}
+	}
+	// values now run from front to back. The front half goes to the front stack and the rest to the back one;
+	// an odd item goes to the side being popped, so that the pop which triggered this always succeeds.
+	half := len(values) / 2
+	if source == deque.back && len(values)%2 == 1 {
+		half++
+	}
+	for i := half - 1; i >= 0; i-- {
+		deque.front.Push(pushFront(deque.front, values[i], deque.combine))
+	}
+	for i := half; i < len(values); i++ {
+		deque.back.Push(pushBack(deque.back, values[i], deque.combine))
+	}
+}
+
+// Internal function which builds the entry for data placed on top of a front stack (data comes before the stack).
+func pushFront(front *gost.SliceStack, data interface{}, combine func(a, b interface{}) interface{}) aggregateEntry {
+	if front.Size() == 0 {
+		return aggregateEntry{value: data, aggregate: data}
+	}
+	return aggregateEntry{value: data, aggregate: combine(data, front.Peek().(aggregateEntry).aggregate)}
+}
+
+// Internal function which builds the entry for data placed on top of a back stack (data comes after the stack).
+func pushBack(back *gost.SliceStack, data interface{}, combine func(a, b interface{}) interface{}) aggregateEntry {
+	if back.Size() == 0 {
+		return aggregateEntry{value: data, aggregate: data}
+	}
+	return aggregateEntry{value: data, aggregate: combine(back.Peek().(aggregateEntry).aggregate, data)}
+}
+
+// Internal function which combines the aggregates held at the top of the front and back stacks.
+func aggregateOf(front, back *gost.SliceStack, combine func(a, b interface{}) interface{}) interface{} {
+	switch {
+	case front.Size() == 0 && back.Size() == 0:
+		return nil
+	case back.Size() == 0:
+		return front.Peek().(aggregateEntry).aggregate
+	case front.Size() == 0:
+		return back.Peek().(aggregateEntry).aggregate
+	}
+	return combine(front.Peek().(aggregateEntry).aggregate, back.Peek().(aggregateEntry).aggregate)
+}
@@ -0,0 +1,135 @@
+package gost
+
+import (
+	"container/heap"
+	"math"
+	"time"
+)
+
+// AgingFunc computes the effective priority of an item given its original priority and the time it has waited.
+type AgingFunc func(priority float64, waited time.Duration) float64
+
+// LinearAging returns an AgingFunc which raises priorities by rate units per second of waiting.
+func LinearAging(rate float64) AgingFunc {
+	return func(priority float64, waited time.Duration) float64 {
+		return priority + rate*waited.Seconds()
+	}
+}
+
+// ExponentialAging returns an AgingFunc which raises priorities by e^(rate * seconds waited) - 1 units.
+func ExponentialAging(rate float64) AgingFunc {
+	return func(priority float64, waited time.Duration) float64 {
+		return priority + math.Expm1(rate*waited.Seconds())
+	}
+}
+
+/*
+AgingPriorityQueue is a heap-based priority queue which prevents starvation of low priority items: the effective
+priority of an item grows with the time it spends in the queue, as defined by an AgingFunc. It allows:
+
+- Enqueuing: inserting an item along with its (original) priority.
+
+- De-queuing: retrieving the item with the highest effective priority, FIFO order being the tiebreaker.
+
+- Measuring: obtaining the maximum wait time among dequeued items, and the current wait time of the oldest item.
+
+Effective priorities are recomputed lazily: Dequeue() refreshes them when at least the refresh interval has elapsed
+since the last refresh (a zero interval refreshes on every call), and Refresh() can be called on a tick. Each refresh
+takes O(n). Time is read from an injectable Clock.
+
+Note that the implementation is NOT thread-safe.
+*/
+type AgingPriorityQueue struct {
+	contents    heapContents
+	counter     uint64 // counter ensures FIFO when priority between elements is equal
+	aging       AgingFunc
+	interval    time.Duration
+	clock       Clock
+	lastRefresh time.Time
+	maxWait     time.Duration
+}
+
+// agingEntry wraps a queued value with its original priority and enqueuing time.
+type agingEntry struct {
+	value    interface{}
+	priority float64
+	enqueued time.Time
+}
+
+// NewAgingPriorityQueue initializes an AgingPriorityQueue using aging to compute effective priorities, refreshed at
+// most once every interval. If clock is nil, the system clock is used.
+func NewAgingPriorityQueue(aging AgingFunc, interval time.Duration, clock Clock) *AgingPriorityQueue {
+	if clock == nil {
+		clock = systemClock{}
+	}
+	return &AgingPriorityQueue{aging: aging, interval: interval, clock: clock, lastRefresh: clock.Now()}
+}
+
+// Enqueue adds an interface item and its priority into the AgingPriorityQueue.
+func (pq *AgingPriorityQueue) Enqueue(item interface{}, priority float64) {
+	if pq.counter >= maxCounter {
+		pq.counter = renormalizeCounters(priorityItemCounters(pq.contents))
+	}
+	entry := &agingEntry{value: item, priority: priority, enqueued: pq.clock.Now()}
+	heap.Push(&pq.contents, newPriorityItem(entry, pq.aging(priority, 0), pq.counter))
+	pq.counter++
+}
+
+// Dequeue removes the item with the highest effective priority, or insertion order when there's no higher priority
+// contents. If the queue is empty, returns nil.
+func (pq *AgingPriorityQueue) Dequeue() interface{} {
+	if pq.Size() == 0 {
+		return nil
+	}
+	now := pq.clock.Now()
+	if now.Sub(pq.lastRefresh) >= pq.interval {
+		pq.refresh(now)
+	}
+	entry := heap.Pop(&pq.contents).(*agingEntry)
+	if waited := now.Sub(entry.enqueued); waited > pq.maxWait {
+		pq.maxWait = waited
+	}
+	if pq.Size() == 0 {
+		pq.counter = 0 // reset FIFO ordering counter once drained
+	}
+	return entry.value
+}
+
+// Refresh recomputes the effective priority of every queued item as of now.
+func (pq *AgingPriorityQueue) Refresh() {
+	pq.refresh(pq.clock.Now())
+}
+
+// MaxWait returns the longest time an item waited in the queue before being dequeued.
+func (pq *AgingPriorityQueue) MaxWait() time.Duration {
+	return pq.maxWait
+}
+
+// OldestWait returns the time the oldest queued item has been waiting so far (zero if empty). Takes O(n).
+func (pq *AgingPriorityQueue) OldestWait() time.Duration {
+	if pq.Size() == 0 {
+		return 0
+	}
+	oldest := pq.contents[0].value.(*agingEntry).enqueued
+	for _, item := range pq.contents[1:] {
+		if enqueued := item.value.(*agingEntry).enqueued; enqueued.Before(oldest) {
+			oldest = enqueued
+		}
+	}
+	return pq.clock.Now().Sub(oldest)
+}
+
+// Size returns the size of the AgingPriorityQueue.
+func (pq *AgingPriorityQueue) Size() int {
+	return pq.contents.Len()
+}
+
+// Internal function which recomputes effective priorities as of now and restores the heap order.
+func (pq *AgingPriorityQueue) refresh(now time.Time) {
+	for _, item := range pq.contents {
+		entry := item.value.(*agingEntry)
+		item.priority = pq.aging(entry.priority, now.Sub(entry.enqueued))
+	}
+	heap.Init(&pq.contents)
+	pq.lastRefresh = now
+}
@@ -0,0 +1,144 @@
+package gost
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+/*
+BatchQueue groups individually enqueued items into batches, buffering the pending ones in any Queue implementation.
+The pending batch is sealed as soon as any of these conditions is met:
+
+- It holds maxItems items.
+
+- The sizes of its items, as computed by a sizer function, add up to maxBytes.
+
+- The linger time has elapsed since its first item was enqueued.
+
+Each of the limits is disabled when zero (or when sizer is nil, for maxBytes). Sealed batches ([]interface{}) are
+returned by Dequeue() and Take() in FIFO order. Flush() seals the pending batch on demand, and Close() does so for
+the last time, rejecting further items. Time is read from an injectable Clock.
+
+Note that the implementation is NOT thread-safe: Take() blocks the calling goroutine, and the queue must not be used
+from any other one in the meantime.
+*/
+type BatchQueue struct {
+	pending  Queue
+	bytes    int
+	started  time.Time // enqueuing time of the first pending item
+	sealed   NodeQueue
+	maxItems int
+	maxBytes int
+	sizer    func(item interface{}) int
+	linger   time.Duration
+	clock    Clock
+	closed   bool
+}
+
+// NewBatchQueue creates a BatchQueue buffering pending items in queue, and sealing batches according to maxItems,
+// maxBytes (as measured by sizer) and linger. If clock is nil, the system clock is used.
+func NewBatchQueue(queue Queue, maxItems, maxBytes int, sizer func(item interface{}) int, linger time.Duration, clock Clock) *BatchQueue {
+	if clock == nil {
+		clock = systemClock{}
+	}
+	return &BatchQueue{pending: queue, maxItems: maxItems, maxBytes: maxBytes, sizer: sizer, linger: linger, clock: clock}
+}
+
+// Enqueue adds data (interface{}) to the pending batch, sealing it if a limit is reached.
+// Returns an error if the BatchQueue is closed.
+func (bq *BatchQueue) Enqueue(data interface{}) error {
+	if bq.closed {
+		return errors.New("cannot Enqueue() on closed queue")
+	}
+	now := bq.clock.Now()
+	bq.expire(now)
+	if bq.pending.Size() == 0 {
+		bq.started = now
+	}
+	bq.pending.Enqueue(data)
+	if bq.maxBytes > 0 && bq.sizer != nil {
+		bq.bytes += bq.sizer(data)
+	}
+	if (bq.maxItems > 0 && bq.pending.Size() >= bq.maxItems) || (bq.maxBytes > 0 && bq.sizer != nil && bq.bytes >= bq.maxBytes) {
+		bq.seal()
+	}
+	return nil
+}
+
+// Dequeue removes the oldest sealed batch, sealing the pending one first if it lingered long enough.
+// Returns the batch ([]interface{}) or nil if there is none.
+func (bq *BatchQueue) Dequeue() interface{} {
+	bq.expire(bq.clock.Now())
+	if bq.sealed.Size() == 0 {
+		return nil
+	}
+	return bq.sealed.Dequeue()
+}
+
+// Take removes the oldest sealed batch, waiting for the pending one to linger long enough if there is none.
+// Returns an error if there are no items at all (or lingering is disabled), or the context error if ctx is done.
+func (bq *BatchQueue) Take(ctx context.Context) ([]interface{}, error) {
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if batch := bq.Dequeue(); batch != nil {
+			return batch.([]interface{}), nil
+		}
+		if bq.pending.Size() == 0 || bq.linger <= 0 {
+			return nil, errors.New("cannot Take() without sealed batches or lingering items")
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-bq.clock.After(bq.started.Add(bq.linger).Sub(bq.clock.Now())):
+		}
+	}
+}
+
+// Flush seals the pending batch (if any), making it available to Dequeue() regardless of the limits.
+func (bq *BatchQueue) Flush() {
+	bq.seal()
+}
+
+// Close flushes the pending batch and rejects any further items. Sealed batches can still be dequeued.
+func (bq *BatchQueue) Close() {
+	bq.seal()
+	bq.closed = true
+}
+
+// Closed responds whether Close() has been called.
+func (bq *BatchQueue) Closed() bool {
+	return bq.closed
+}
+
+// Size returns the amount of sealed batches ready to be dequeued.
+func (bq *BatchQueue) Size() int {
+	return bq.sealed.Size()
+}
+
+// Pending returns the amount of items in the pending batch.
+func (bq *BatchQueue) Pending() int {
+	return bq.pending.Size()
+}
+
+// Internal function which seals the pending batch if it has lingered for long enough as of now.
+func (bq *BatchQueue) expire(now time.Time) {
+	if bq.linger > 0 && bq.pending.Size() > 0 && now.Sub(bq.started) >= bq.linger {
+		bq.seal()
+	}
+}
+
+// Internal function which moves the pending items (if any) into a new sealed batch.
+func (bq *BatchQueue) seal() {
+	if bq.pending.Size() == 0 {
+		return
+	}
+	batch := make([]interface{}, 0, bq.pending.Size())
+	for bq.pending.Size() > 0 {
+		batch = append(batch, bq.pending.Dequeue())
+	}
+	bq.sealed.Enqueue(batch)
+	bq.bytes = 0
+}
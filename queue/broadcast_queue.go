@@ -0,0 +1,199 @@
+package gost
+
+import (
+	"errors"
+
+	"github.com/christat/gost/list"
+)
+
+// LagPolicy defines how a BroadcastQueue deals with subscribers lagging behind by more than its maximum lag.
+type LagPolicy int
+
+const (
+	// DropOldest makes slow subscribers skip their oldest unread items, which are lost for them.
+	DropOldest LagPolicy = iota
+	// Disconnect closes slow subscribers, which stop receiving items.
+	Disconnect
+	// Reject makes Publish() fail while any subscriber is lagging behind by the maximum lag.
+	Reject
+)
+
+/*
+BroadcastQueue is a publish/subscribe log in which every published item is delivered to every subscriber, each of
+them reading at its own pace through a cursor. Items are kept in a chain of gost.Nodes, and reclaimed as soon as
+every cursor has passed them. Each node counts the cursors resting on it, so that reclaiming never scans the
+subscribers. It allows:
+
+- Publishing: appending an item to the log in O(1) (plus the cost of enforcing the lag policy).
+
+- Subscribing: obtaining a Subscriber which either reads new items only, or replays the log from the oldest item
+still retained. Keeping a minimum amount of items retained allows late subscribers to replay recent history.
+
+- Limiting lag: subscribers falling behind by more than a maximum lag are dealt with according to a LagPolicy.
+
+Note that the implementation is NOT thread-safe.
+*/
+type BroadcastQueue struct {
+	before      *gost.Node // node preceding the oldest retained item; its Next is the oldest retained item
+	tail        *gost.Node // newest item, or before if the log is empty
+	subscribers map[*Subscriber]struct{}
+	retain      int
+	maxLag      int
+	policy      LagPolicy
+}
+
+// broadcastEntry wraps a published item with its sequence number.
+type broadcastEntry struct {
+	sequence uint64
+	value    interface{}
+	cursors  int // amount of subscribers whose cursor is this node
+}
+
+/*
+Subscriber is a cursor over a BroadcastQueue. It reads every item published after its position, in order.
+*/
+type Subscriber struct {
+	queue   *BroadcastQueue
+	cursor  *gost.Node // last read node; its Next is the next item to read
+	dropped uint64
+	closed  bool
+}
+
+// NewBroadcastQueue creates an empty BroadcastQueue which retains at least the retain most recent items for replay,
+// and applies policy to subscribers lagging behind by more than maxLag items (no limit if zero).
+func NewBroadcastQueue(retain, maxLag int, policy LagPolicy) *BroadcastQueue {
+	sentinel := &gost.Node{Data: &broadcastEntry{}}
+	return &BroadcastQueue{
+		before:      sentinel,
+		tail:        sentinel,
+		subscribers: make(map[*Subscriber]struct{}),
+		retain:      retain,
+		maxLag:      maxLag,
+		policy:      policy,
+	}
+}
+
+// Publish appends item to the log, making it available to every subscriber.
+// Returns an error under the Reject policy if a subscriber is lagging behind by the maximum lag.
+func (bq *BroadcastQueue) Publish(item interface{}) error {
+	if bq.maxLag > 0 && bq.policy == Reject {
+		for subscriber := range bq.subscribers {
+			if subscriber.Lag() >= bq.maxLag {
+				return errors.New("cannot Publish() subscriber lagging behind")
+			}
+		}
+	}
+	node := &gost.Node{Data: &broadcastEntry{sequence: sequenceOf(bq.tail) + 1, value: item}}
+	bq.tail.Next = node
+	bq.tail = node
+	if bq.maxLag > 0 && bq.policy != Reject {
+		for subscriber := range bq.subscribers {
+			if lag := subscriber.Lag(); lag > bq.maxLag {
+				if bq.policy == Disconnect {
+					subscriber.Unsubscribe()
+					continue
+				}
+				for ; lag > bq.maxLag; lag-- {
+					subscriber.moveTo(subscriber.cursor.Next)
+					subscriber.dropped++
+				}
+			}
+		}
+	}
+	bq.reclaim()
+	return nil
+}
+
+// Subscribe creates a Subscriber. If replay is true it starts from the oldest retained item, otherwise it only
+// receives items published from now on.
+func (bq *BroadcastQueue) Subscribe(replay bool) *Subscriber {
+	subscriber := &Subscriber{queue: bq}
+	if replay {
+		subscriber.moveTo(bq.before)
+	} else {
+		subscriber.moveTo(bq.tail)
+	}
+	bq.subscribers[subscriber] = struct{}{}
+	return subscriber
+}
+
+// Subscribers returns the amount of active subscribers.
+func (bq *BroadcastQueue) Subscribers() int {
+	return len(bq.subscribers)
+}
+
+// Size returns the amount of items currently retained by the log.
+func (bq *BroadcastQueue) Size() int {
+	return int(sequenceOf(bq.tail) - sequenceOf(bq.before))
+}
+
+// Internal function which drops the oldest items once every subscriber has read them, keeping at least retain items.
+// No cursor ever precedes before, so the oldest item has been read by everyone once no cursor rests on before.
+// Runs in amortized O(1), as every node is passed once.
+func (bq *BroadcastQueue) reclaim() {
+	for bq.before != bq.tail && bq.Size() > bq.retain && entryOf(bq.before).cursors == 0 {
+		// Nodes are not unlinked, since subscribers may still hold them as cursors; they are garbage collected
+		// once no cursor refers to them any more.
+		bq.before = bq.before.Next
+	}
+}
+
+// Next reads the next item. Returns false if there is none yet, or if the Subscriber is closed.
+func (s *Subscriber) Next() (interface{}, bool) {
+	if s.closed || s.cursor.Next == nil {
+		return nil, false
+	}
+	s.moveTo(s.cursor.Next)
+	s.queue.reclaim()
+	return entryOf(s.cursor).value, true
+}
+
+// Lag returns the amount of published items the Subscriber has not read yet.
+func (s *Subscriber) Lag() int {
+	if s.closed {
+		return 0
+	}
+	return int(sequenceOf(s.queue.tail) - sequenceOf(s.cursor))
+}
+
+// Dropped returns the amount of items skipped by the Subscriber under the DropOldest policy.
+func (s *Subscriber) Dropped() uint64 {
+	return s.dropped
+}
+
+// Closed responds whether the Subscriber was closed, either by Unsubscribe() or by the Disconnect policy.
+func (s *Subscriber) Closed() bool {
+	return s.closed
+}
+
+// Unsubscribe closes the Subscriber, allowing the items it did not read to be reclaimed.
+func (s *Subscriber) Unsubscribe() {
+	if s.closed {
+		return
+	}
+	s.closed = true
+	delete(s.queue.subscribers, s)
+	s.moveTo(nil)
+	s.queue.reclaim()
+}
+
+// Internal function which moves the cursor of the Subscriber to node (nil to release it), keeping cursor counts.
+func (s *Subscriber) moveTo(node *gost.Node) {
+	if s.cursor != nil {
+		entryOf(s.cursor).cursors--
+	}
+	if node != nil {
+		entryOf(node).cursors++
+	}
+	s.cursor = node
+}
+
+// Internal function returning the sequence number of the item held by node.
+func sequenceOf(node *gost.Node) uint64 {
+	return entryOf(node).sequence
+}
+
+// Internal function returning the entry held by node.
+func entryOf(node *gost.Node) *broadcastEntry {
+	return node.Data.(*broadcastEntry)
+}
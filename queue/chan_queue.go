@@ -0,0 +1,221 @@
+package gost
+
+import (
+	"context"
+	"errors"
+
+	"github.com/christat/gost/stack"
+)
+
+/*
+ChanQueue bridges a Queue or Stack implementation to Go channels, so that it can be used from select-based code.
+A single goroutine owns the wrapped container for the whole lifetime of the ChanQueue: values sent to In() are
+added to it, and its next value (the head of a queue, the top of a stack) is offered on Out() whenever it is not
+empty. Backed by a NodeQueue, it behaves as a channel with an unbounded buffer.
+
+As the goroutine outlives any single send or receive, its context is given once, on construction, rather than per
+call to In() or Out(). Callers bound individual operations by selecting on their own context alongside them:
+
+	select {
+	case value := <-cq.Out():
+	case <-ctx.Done():
+	}
+
+Shutting down is done by either:
+
+- Closing In(): remaining items are still delivered on Out(), which is closed once the container is drained.
+
+- Cancelling the context: Out() is closed right away, and undelivered items are left in the wrapped container.
+
+Done() is closed when the goroutine exits; only then may the wrapped container be accessed directly again. Note
+that if In() is closed but Out() is not read until it is drained, the goroutine lives on until ctx is cancelled.
+
+Once the ChanQueue has shut down nothing receives from In() any more, so a plain send would block forever. Producers
+which may outlive it must use Send(), or select on Done() themselves:
+
+	select {
+	case cq.In() <- value:
+	case <-cq.Done():
+	}
+*/
+type ChanQueue struct {
+	buffer chanBuffer
+	in     chan interface{}
+	out    chan interface{}
+	done   chan struct{}
+}
+
+// NewChanQueue starts bridging queue to channels until ctx is done or In() is closed and the queue is drained.
+// Items already held by queue are delivered first.
+func NewChanQueue(ctx context.Context, queue Queue) *ChanQueue {
+	return newChanQueue(ctx, &queueBuffer{queue: queue})
+}
+
+// NewChanStack starts bridging stack to channels until ctx is done or In() is closed and the stack is drained.
+// Out() offers the top of the stack, so values are delivered in LIFO order: a value sent while another one is
+// being offered is delivered first.
+func NewChanStack(ctx context.Context, stack gost.Stack) *ChanQueue {
+	return newChanQueue(ctx, stackBuffer{stack: stack})
+}
+
+// Internal function which starts the goroutine owning buffer.
+func newChanQueue(ctx context.Context, buffer chanBuffer) *ChanQueue {
+	cq := &ChanQueue{
+		buffer: buffer,
+		in:     make(chan interface{}),
+		out:    make(chan interface{}),
+		done:   make(chan struct{}),
+	}
+	go cq.run(ctx)
+	return cq
+}
+
+// In returns the channel values are added from. Close it to shut the ChanQueue down gracefully.
+// Sending on it blocks forever once the ChanQueue has shut down; see Send().
+func (cq *ChanQueue) In() chan<- interface{} {
+	return cq.in
+}
+
+// Send adds value through In(), unless the ChanQueue shuts down first. Returns an error if the ChanQueue has
+// shut down. Like any send on In(), it must not be called once In() is closed.
+func (cq *ChanQueue) Send(value interface{}) error {
+	select {
+	case cq.in <- value:
+		return nil
+	case <-cq.done:
+		return errors.New("cannot Send() ChanQueue shut down")
+	}
+}
+
+// Out returns the channel values are delivered on, in the order of the wrapped container. It is closed on shutdown.
+func (cq *ChanQueue) Out() <-chan interface{} {
+	return cq.out
+}
+
+// Done returns a channel which is closed once the ChanQueue has shut down.
+func (cq *ChanQueue) Done() <-chan struct{} {
+	return cq.done
+}
+
+// Internal function run by the goroutine owning the container.
+func (cq *ChanQueue) run(ctx context.Context) {
+	defer close(cq.done)
+	defer close(cq.out)
+	in := cq.in
+	for {
+		head, ok := cq.buffer.head()
+		var out chan interface{} // nil (blocking forever) while there is nothing to deliver
+		if ok {
+			out = cq.out
+		} else if in == nil {
+			return
+		}
+		select {
+		case value, ok := <-in:
+			if !ok {
+				in = nil
+				continue
+			}
+			cq.buffer.add(value)
+		case out <- head:
+			cq.buffer.remove()
+		case <-ctx.Done():
+			cq.buffer.restore()
+			return
+		}
+	}
+}
+
+/*
+	The types defined below adapt queues and stacks to the goroutine of a ChanQueue.
+*/
+
+// chanBuffer is the container owned by the goroutine of a ChanQueue.
+type chanBuffer interface {
+	add(value interface{})
+	head() (interface{}, bool) // returns the next value to deliver, if any
+	remove()                   // removes the value returned by head()
+	restore()                  // undoes any change made by head(), leaving the container to its owner
+}
+
+// queueBuffer adapts a Queue, which offers no way to peek: its head is kept aside until delivered.
+type queueBuffer struct {
+	queue   Queue
+	value   interface{}
+	holding bool
+}
+
+func (qb *queueBuffer) add(value interface{}) {
+	qb.queue.Enqueue(value)
+}
+
+func (qb *queueBuffer) head() (interface{}, bool) {
+	if !qb.holding && qb.queue.Size() > 0 {
+		qb.value, qb.holding = qb.queue.Dequeue(), true
+	}
+	return qb.value, qb.holding
+}
+
+func (qb *queueBuffer) remove() {
+	qb.value, qb.holding = nil, false
+}
+
+func (qb *queueBuffer) restore() {
+	if qb.holding {
+		// Put the undelivered head back in front, rotating the queue as it offers no way to push there.
+		qb.queue.Enqueue(qb.value)
+		for i := qb.queue.Size() - 1; i > 0; i-- {
+			qb.queue.Enqueue(qb.queue.Dequeue())
+		}
+		qb.remove()
+	}
+}
+
+// stackBuffer adapts a Stack, whose top is peeked at until delivered.
+type stackBuffer struct {
+	stack gost.Stack
+}
+
+func (sb stackBuffer) add(value interface{}) {
+	sb.stack.Push(value)
+}
+
+func (sb stackBuffer) head() (interface{}, bool) {
+	if sb.stack.Size() == 0 {
+		return nil, false
+	}
+	return sb.stack.Peek(), true
+}
+
+func (sb stackBuffer) remove() {
+	sb.stack.Pop()
+}
+
+func (sb stackBuffer) restore() {}
+
+// FromChan returns a ChanQueue, backed by a NodeQueue, which is fed from ch by a forwarding goroutine; it does not
+// block. In() is owned by the forwarder, which closes it once ch is closed, so Out() is closed after delivering every
+// value received from ch. Cancelling ctx stops both goroutines, even if ch is never closed.
+func FromChan(ctx context.Context, ch <-chan interface{}) *ChanQueue {
+	cq := NewChanQueue(ctx, new(NodeQueue))
+	go cq.forward(ch)
+	return cq
+}
+
+// Internal function which sends every value received from ch to the ChanQueue, closing In() once ch is closed.
+func (cq *ChanQueue) forward(ch <-chan interface{}) {
+	for {
+		select {
+		case value, ok := <-ch:
+			if !ok {
+				close(cq.in)
+				return
+			}
+			if cq.Send(value) != nil {
+				return
+			}
+		case <-cq.done:
+			return
+		}
+	}
+}
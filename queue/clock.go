@@ -0,0 +1,24 @@
+package gost
+
+import "time"
+
+// Clock abstracts the source of time of time-dependent queues, so that it can be replaced (e.g. in tests).
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+	// After waits for the duration to elapse and then sends the current time on the returned channel.
+	After(d time.Duration) <-chan time.Time
+}
+
+// systemClock is the default Clock, backed by the time package.
+type systemClock struct{}
+
+// Now returns the current local time.
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// After behaves as time.After.
+func (systemClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
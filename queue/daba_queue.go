@@ -0,0 +1,159 @@
+package gost
+
+/*
+DABAQueue is a FIFO queue keeping a running aggregate of its contents, like AggregatingQueue, but implemented as the
+De-Amortized Banker's Aggregator (DABA) by Tangwongsan, Hirzel and Schneider. Rather than flipping a whole stack at
+once when the front runs dry, it performs one step of that work on every operation, so that no single operation
+costs more than a constant amount of combine calls. It allows:
+
+- Enqueuing: inserting an item into the last position of the queue in worst-case O(1).
+
+- De-queuing: retrieving the first item in the queue in worst-case O(1).
+
+- Aggregating: obtaining combine(x1, combine(x2, ... xn)) over the queue contents in worst-case O(1).
+
+Items live in a deque of fixed-size chunks, so that the storage never has to be copied as the queue grows. Prefer
+it over AggregatingQueue when latency spikes matter more than throughput, e.g. for rate monitors.
+
+Note that the implementation is NOT thread-safe.
+*/
+type DABAQueue struct {
+	// Positions splitting the deque into sublists, in order: f <= l <= r <= a <= b <= e. The front, [f, b), holds
+	// aggregates from each item up to b, except for [l, a) which is being rebuilt; the back, [b, e), holds
+	// aggregates from b up to each item.
+	f, l, r, a, b, e dabaPosition
+	size             int
+	combine          func(a, b interface{}) interface{}
+}
+
+// dabaChunkSize is the amount of items held by each chunk of a DABAQueue.
+const dabaChunkSize = 64
+
+// dabaChunk is a fixed-size block of items and their aggregates, linked to its neighbours.
+type dabaChunk struct {
+	values     [dabaChunkSize]interface{}
+	aggregates [dabaChunkSize]interface{}
+	prev, next *dabaChunk
+}
+
+// dabaPosition points to a slot of a chunk; positions are comparable with ==.
+type dabaPosition struct {
+	chunk *dabaChunk
+	index int
+}
+
+// dabaAggregate is a partial aggregate, which may be empty (the identity of combine).
+type dabaAggregate struct {
+	value   interface{}
+	present bool
+}
+
+// NewDABAQueue creates an empty DABAQueue which aggregates its contents using combine.
+func NewDABAQueue(combine func(a, b interface{}) interface{}) *DABAQueue {
+	start := dabaPosition{chunk: new(dabaChunk)}
+	return &DABAQueue{f: start, l: start, r: start, a: start, b: start, e: start, combine: combine}
+}
+
+// Enqueue adds data (interface{}) to the tail of the queue.
+func (queue *DABAQueue) Enqueue(data interface{}) {
+	aggregate := queue.join(queue.sigma(queue.b, queue.e, queue.e.prev()), dabaAggregate{value: data, present: true})
+	queue.e.chunk.values[queue.e.index] = data
+	queue.e.chunk.aggregates[queue.e.index] = aggregate.value
+	if queue.e.index == dabaChunkSize-1 {
+		queue.e.chunk.next = &dabaChunk{prev: queue.e.chunk}
+	}
+	queue.e = queue.e.next()
+	queue.size++
+	queue.fixup()
+}
+
+// Dequeue removes the head of the queue. Returns the data or nil if empty.
+func (queue *DABAQueue) Dequeue() interface{} {
+	if queue.size == 0 {
+		return nil
+	}
+	chunk, index := queue.f.chunk, queue.f.index
+	data := chunk.values[index]
+	chunk.values[index], chunk.aggregates[index] = nil, nil
+	queue.f = queue.f.next()
+	if queue.f.chunk != chunk {
+		queue.f.chunk.prev = nil // release the drained chunk
+	}
+	queue.size--
+	queue.fixup()
+	return data
+}
+
+// Aggregate returns the combination of every item in the queue, in queue order. Returns nil if empty.
+func (queue *DABAQueue) Aggregate() interface{} {
+	return queue.join(queue.sigma(queue.f, queue.b, queue.f), queue.sigma(queue.b, queue.e, queue.e.prev())).value
+}
+
+// Size returns the length of the DABAQueue.
+func (queue *DABAQueue) Size() int {
+	return queue.size
+}
+
+// Internal function which performs one step of rebuilding the front, restoring the invariants after an operation.
+func (queue *DABAQueue) fixup() {
+	if queue.f == queue.b {
+		// The front is empty; the back holds at most one item, whose aggregate is valid either way.
+		queue.l, queue.r, queue.a, queue.b = queue.e, queue.e, queue.e, queue.e
+		return
+	}
+	if queue.l == queue.b {
+		// The front is fully built and as long as the back: start rebuilding both as the new front.
+		queue.l, queue.a, queue.b = queue.f, queue.e, queue.e
+	}
+	if queue.l == queue.r {
+		// Nothing left to rebuild; the item at l already aggregates up to b.
+		queue.l, queue.r, queue.a = queue.l.next(), queue.r.next(), queue.a.next()
+		return
+	}
+	// [l, r) aggregates up to r, [r, a) from r onwards and [a, b) up to b: complete the aggregate at l, and
+	// extend [a, b) by one item backwards.
+	l := queue.join(queue.join(queue.sigma(queue.l, queue.r, queue.l), queue.sigma(queue.r, queue.a, queue.a.prev())),
+		queue.sigma(queue.a, queue.b, queue.a))
+	queue.l.chunk.aggregates[queue.l.index] = l.value
+	queue.l = queue.l.next()
+	queue.a = queue.a.prev()
+	a := queue.join(dabaAggregate{value: queue.a.chunk.values[queue.a.index], present: true},
+		queue.sigma(queue.a.next(), queue.b, queue.a.next()))
+	queue.a.chunk.aggregates[queue.a.index] = a.value
+}
+
+// Internal function returning the aggregate stored at at, or an empty aggregate if the sublist [from, to) is empty.
+func (queue *DABAQueue) sigma(from, to, at dabaPosition) dabaAggregate {
+	if from == to {
+		return dabaAggregate{}
+	}
+	return dabaAggregate{value: at.chunk.aggregates[at.index], present: true}
+}
+
+// Internal function combining two partial aggregates, in order.
+func (queue *DABAQueue) join(x, y dabaAggregate) dabaAggregate {
+	switch {
+	case !x.present:
+		return y
+	case !y.present:
+		return x
+	}
+	return dabaAggregate{value: queue.combine(x.value, y.value), present: true}
+}
+
+// Internal function returning the position following p; its chunk must be allocated already.
+func (p dabaPosition) next() dabaPosition {
+	if p.index == dabaChunkSize-1 {
+		return dabaPosition{chunk: p.chunk.next}
+	}
+	return dabaPosition{chunk: p.chunk, index: p.index + 1}
+}
+
+// Internal function returning the position preceding p. Only valid when used to read a non-empty sublist, or
+// within the retained chunks.
+func (p dabaPosition) prev() dabaPosition {
+	if p.index == 0 {
+		return dabaPosition{chunk: p.chunk.prev, index: dabaChunkSize - 1}
+	}
+	return dabaPosition{chunk: p.chunk, index: p.index - 1}
+}
@@ -0,0 +1,168 @@
+package gost
+
+import "errors"
+
+/*
+DaryPriorityQueue is an alternative backend for PriorityQueue and MinPriorityQueue, which use it when created with
+NewPriorityQueueWithArity() and NewMinPriorityQueueWithArity(); it can be used on its own as well. Instead of a binary heap of *priorityItem pointers, it keeps a d-ary heap (4-ary or 8-ary are
+good choices) laid out as a struct of arrays: priorities, counters and values are stored in contiguous slices.
+This avoids one allocation per item and makes sift-down scan sibling priorities from the same cache lines,
+at the cost of comparing more children per level.
+
+This implementation uses FIFO order as tiebreaker when elements have the same priority.
+
+Note that the implementation is NOT thread-safe.
+*/
+type DaryPriorityQueue struct {
+	arity    int
+	sign     float64 // keys are stored as sign * priority, so the heap always dequeues the lowest key first
+	keys     []float64
+	counters []uint64 // counters ensure FIFO when priority between elements is equal
+	values   []interface{}
+	counter  uint64
+}
+
+// NewDaryPriorityQueue initializes a d-ary heap priority queue dequeuing the highest priorities first.
+// arity is the amount of children per heap node; values lower than 2 default to 4.
+func NewDaryPriorityQueue(arity int) *DaryPriorityQueue {
+	return newDaryPriorityQueue(arity, -1)
+}
+
+// NewDaryMinPriorityQueue initializes a d-ary heap priority queue dequeuing the lowest priorities first.
+// arity is the amount of children per heap node; values lower than 2 default to 4.
+func NewDaryMinPriorityQueue(arity int) *DaryPriorityQueue {
+	return newDaryPriorityQueue(arity, 1)
+}
+
+// Internal function which initializes the queue with the given arity and ordering sign.
+func newDaryPriorityQueue(arity int, sign float64) *DaryPriorityQueue {
+	if arity < 2 {
+		arity = 4
+	}
+	return &DaryPriorityQueue{arity: arity, sign: sign}
+}
+
+// Enqueue adds an interface item and its priority into the DaryPriorityQueue.
+func (pq *DaryPriorityQueue) Enqueue(item interface{}, priority float64) {
+	pq.append(item, priority)
+	pq.siftUp(len(pq.keys) - 1)
+}
+
+// EnqueueAll adds items into the DaryPriorityQueue, each of them with the priority at the same index. Items are
+// considered to be enqueued in slice order, and the heap is rebuilt bottom-up in O(n) unless only a few items are
+// added. Returns an error if items and priorities differ in length.
+func (pq *DaryPriorityQueue) EnqueueAll(items []interface{}, priorities []float64) error {
+	if len(items) != len(priorities) {
+		return errors.New("cannot EnqueueAll() items and priorities differ in length")
+	}
+	if len(items) < pq.Size() {
+		// Pushing a few items into a large heap is cheaper than rebuilding it.
+		for i, item := range items {
+			pq.Enqueue(item, priorities[i])
+		}
+		return nil
+	}
+	for i, item := range items {
+		pq.append(item, priorities[i])
+	}
+	for i := (len(pq.keys) - 2) / pq.arity; i >= 0; i-- {
+		pq.siftDown(i)
+	}
+	return nil
+}
+
+// Dequeue removes the next item in the DaryPriorityQueue according to its ordering, or insertion order when
+// priorities are equal. If the queue is empty, returns nil.
+func (pq *DaryPriorityQueue) Dequeue() interface{} {
+	if pq.Size() == 0 {
+		return nil
+	}
+	value := pq.values[0]
+	last := len(pq.keys) - 1
+	pq.keys[0], pq.counters[0], pq.values[0] = pq.keys[last], pq.counters[last], pq.values[last]
+	pq.values[last] = nil
+	pq.keys, pq.counters, pq.values = pq.keys[:last], pq.counters[:last], pq.values[:last]
+	if last > 0 {
+		pq.siftDown(0)
+	} else {
+		pq.counter = 0 // reset FIFO ordering counter once drained
+	}
+	return value
+}
+
+// Size returns the size of the DaryPriorityQueue.
+func (pq *DaryPriorityQueue) Size() int {
+	return len(pq.keys)
+}
+
+// Internal function which appends item at the end of the arrays, without restoring the heap order.
+func (pq *DaryPriorityQueue) append(item interface{}, priority float64) {
+	if pq.sign == 0 {
+		// Zero value queues behave like PriorityQueue, with the default arity.
+		*pq = *newDaryPriorityQueue(pq.arity, -1)
+	}
+	if pq.counter >= maxCounter {
+		counters := make([]*uint64, len(pq.counters))
+		for i := range pq.counters {
+			counters[i] = &pq.counters[i]
+		}
+		pq.counter = renormalizeCounters(counters)
+	}
+	pq.keys = append(pq.keys, pq.sign*priority)
+	pq.counters = append(pq.counters, pq.counter)
+	pq.values = append(pq.values, item)
+	pq.counter++
+}
+
+// Internal function responding whether the item at index i should be dequeued before the one at j.
+func (pq *DaryPriorityQueue) less(i, j int) bool {
+	if pq.keys[i] == pq.keys[j] {
+		return pq.counters[i] < pq.counters[j]
+	}
+	return pq.keys[i] < pq.keys[j]
+}
+
+// Internal function which swaps the items at i and j across all three arrays.
+func (pq *DaryPriorityQueue) swap(i, j int) {
+	pq.keys[i], pq.keys[j] = pq.keys[j], pq.keys[i]
+	pq.counters[i], pq.counters[j] = pq.counters[j], pq.counters[i]
+	pq.values[i], pq.values[j] = pq.values[j], pq.values[i]
+}
+
+// Internal function which moves the item at i up until its parent is dequeued before it.
+func (pq *DaryPriorityQueue) siftUp(i int) {
+	for i > 0 {
+		parent := (i - 1) / pq.arity
+		if !pq.less(i, parent) {
+			return
+		}
+		pq.swap(i, parent)
+		i = parent
+	}
+}
+
+// Internal function which moves the item at i down until it is dequeued before all of its children.
+func (pq *DaryPriorityQueue) siftDown(i int) {
+	size := len(pq.keys)
+	for {
+		first := pq.arity*i + 1
+		if first >= size {
+			return
+		}
+		last := first + pq.arity
+		if last > size {
+			last = size
+		}
+		best := first
+		for child := first + 1; child < last; child++ {
+			if pq.less(child, best) {
+				best = child
+			}
+		}
+		if !pq.less(best, i) {
+			return
+		}
+		pq.swap(i, best)
+		i = best
+	}
+}
@@ -0,0 +1,37 @@
+package gost
+
+import (
+	"math"
+	"sort"
+)
+
+/*
+	Priority queues break ties between equal priorities with a 64-bit insertion counter, which grows monotonically
+	for as long as the queue holds items and goes back to zero whenever it is drained. Wrapping around would take
+	centuries of continuous insertions, but should the counter ever reach maxCounter, the counters of all queued
+	items are renormalized to 0..n-1 (preserving their relative order) before carrying on. FIFO order therefore
+	holds over arbitrarily long lifetimes, even for queues which are never fully drained.
+*/
+
+// maxCounter is the counter value which triggers renormalization. It is only lowered by tests, so that
+// renormalization can be exercised.
+var maxCounter uint64 = math.MaxUint64
+
+// Internal function which renumbers the referenced counters as 0..n-1, keeping their relative order.
+// Returns the next counter to be assigned.
+func renormalizeCounters(counters []*uint64) uint64 {
+	sort.Slice(counters, func(i, j int) bool { return *counters[i] < *counters[j] })
+	for i, counter := range counters {
+		*counter = uint64(i)
+	}
+	return uint64(len(counters))
+}
+
+// Internal function returning references to the counters of the given priorityItems.
+func priorityItemCounters(items []*priorityItem) []*uint64 {
+	counters := make([]*uint64, len(items))
+	for i, item := range items {
+		counters[i] = &item.counter
+	}
+	return counters
+}
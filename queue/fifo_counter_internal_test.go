@@ -0,0 +1,243 @@
+package gost
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+)
+
+/*
+	FIFO order among items of equal priority relies on insertion counters. The property tests below keep queues
+	busy for a long time, and drain them over and over, checking that order survives. Renormalization only
+	triggers once a counter reaches maxCounter, which no test could reach in practice: the renormalization tests
+	lower maxCounter so that counters are renormalized over and over while items are still queued.
+
+	They live in the package itself, rather than in the test directory, as they need access to maxCounter and
+	to the counters of every queue.
+*/
+
+// longLivedOperations is the amount of operations of the long-running property test (reduced with -short).
+const longLivedOperations = 2000000
+
+// fifoCase adapts a priority queue to the operations used by the FIFO tests.
+type fifoCase struct {
+	name     string
+	min      bool                             // whether lower priorities are dequeued first
+	monotone bool                             // whether priorities may not decrease below the last dequeued one
+	enqueue  func(item int, priority float64) // enqueues item with priority
+	dequeue  func() interface{}               // dequeues the next item, returning nil if empty
+	counter  func() uint64                    // returns the next counter of the queue
+}
+
+// Long-running property test: queues are kept busy for a long time without ever being fully drained, and must
+// keep returning items of equal priority in insertion order. Run with -short for a reduced number of operations.
+func TestPriorityQueues_LongLivedFIFO(t *testing.T) {
+	const levels = 4
+	operations := longLivedOperations
+	if testing.Short() {
+		operations = 10000
+	}
+	for _, c := range fifoCases(operations) {
+		if c.monotone {
+			continue // random priorities would go below the last dequeued one
+		}
+		// model holds one FIFO list of items per priority level.
+		var model [levels][]int
+		size := 0
+		random := rand.New(rand.NewSource(42))
+		for i := 0; i < operations; i++ {
+			// Keep between 1 and ~100 items queued, never draining the queue.
+			if size < 2 || (size < 100 && random.Intn(2) == 0) {
+				priority := random.Intn(levels)
+				c.enqueue(i, float64(priority))
+				model[priority] = append(model[priority], i)
+				size++
+				continue
+			}
+			level := levels - 1
+			for len(model[level]) == 0 {
+				level--
+			}
+			if c.min {
+				for level = 0; len(model[level]) == 0; level++ {
+				}
+			}
+			expected := model[level][0]
+			model[level] = model[level][1:]
+			size--
+			if value := c.dequeue(); value != expected {
+				t.Fatalf("%v: Dequeue() failed after %v operations: returned: %v, expected: %v", c.name, i, value, expected)
+			}
+		}
+	}
+}
+
+// Draining a queue resets its counter; items enqueued afterwards must still respect FIFO order among themselves.
+func TestPriorityQueues_FIFOAfterDrain(t *testing.T) {
+	for _, c := range fifoCases(10) {
+		for round := 0; round < 3; round++ {
+			for i := 0; i < 10; i++ {
+				c.enqueue(i, 1)
+			}
+			for i := 0; i < 10; i++ {
+				if value := c.dequeue(); value != i {
+					t.Fatalf("%v: Dequeue() failed in round %v: returned: %v, expected: %v", c.name, round, value, i)
+				}
+			}
+			if value := c.dequeue(); value != nil {
+				t.Fatalf("%v: Dequeue() returned non-nil value when empty: %v", c.name, value)
+			}
+		}
+	}
+}
+
+// test helper function; lowers maxCounter for the duration of the test.
+func lowerMaxCounter(t *testing.T, threshold uint64) {
+	previous := maxCounter
+	maxCounter = threshold
+	t.Cleanup(func() { maxCounter = previous })
+}
+
+func TestRenormalization_FIFO(t *testing.T) {
+	lowerMaxCounter(t, 8)
+	const rounds = 50
+	for _, c := range fifoCases(rounds * 3) {
+		t.Run(c.name, func(t *testing.T) {
+			enqueued, dequeued := 0, 0
+			for round := 0; round < rounds; round++ {
+				for i := 0; i < 3; i++ {
+					c.enqueue(enqueued, 1)
+					enqueued++
+				}
+				for i := 0; i < 2; i++ {
+					if value := c.dequeue(); value != dequeued {
+						t.Fatalf("Dequeue() failed: returned: %v, expected: %v", value, dequeued)
+					}
+					dequeued++
+				}
+			}
+			// Without renormalization, the counter would have reached the amount of enqueued items.
+			if counter := c.counter(); counter >= uint64(enqueued) {
+				t.Fatalf("Enqueue() error; expected counters to be renormalized below %v, got: %v", enqueued, counter)
+			}
+			for dequeued < enqueued {
+				if value := c.dequeue(); value != dequeued {
+					t.Fatalf("Dequeue() failed: returned: %v, expected: %v", value, dequeued)
+				}
+				dequeued++
+			}
+		})
+	}
+}
+
+func TestRenormalization_TopK(t *testing.T) {
+	lowerMaxCounter(t, 8)
+	tk := NewTopK(4)
+	for i := 0; i < 100; i++ {
+		tk.Offer(i, float64(i/10)) // blocks of 10 items with equal priority
+	}
+	if tk.counter >= 100 {
+		t.Fatalf("Offer() error; expected counters to be renormalized, got: %v", tk.counter)
+	}
+	// Among the best block, the earliest offered items are retained.
+	for i, value := range tk.Sorted() {
+		if value != 90+i {
+			t.Fatalf("Sorted() failed: returned: %v, expected: %v", value, 90+i)
+		}
+	}
+}
+
+// test helper function; returns a fifoCase for every priority queue relying on FIFO counters, with integer keys
+// below capacity.
+func fifoCases(capacity int) []fifoCase {
+	pq, minPQ := NewPriorityQueue(), NewMinPriorityQueue()
+	dary := NewDaryPriorityQueue(4)
+	aging := NewAgingPriorityQueue(LinearAging(0), time.Hour, nil)
+	pairing, leftist := NewPairingHeap(), NewLeftistHeap()
+	indexed, intIndexed := NewIndexedMinPriorityQueue[int](), NewIntIndexedMinPriorityQueue(capacity)
+	radix := NewRadixHeap()
+	immutable := NewImmutablePriorityQueue()
+	return []fifoCase{
+		{
+			name:    "PriorityQueue",
+			enqueue: func(item int, priority float64) { pq.Enqueue(item, priority) },
+			dequeue: pq.Dequeue,
+			counter: func() uint64 { return pq.counter },
+		},
+		{
+			name:    "MinPriorityQueue",
+			min:     true,
+			enqueue: func(item int, priority float64) { minPQ.Enqueue(item, priority) },
+			dequeue: minPQ.Dequeue,
+			counter: func() uint64 { return minPQ.counter },
+		},
+		{
+			name:    "DaryPriorityQueue",
+			enqueue: func(item int, priority float64) { dary.Enqueue(item, priority) },
+			dequeue: dary.Dequeue,
+			counter: func() uint64 { return dary.counter },
+		},
+		{
+			name:    "AgingPriorityQueue",
+			enqueue: func(item int, priority float64) { aging.Enqueue(item, priority) },
+			dequeue: aging.Dequeue,
+			counter: func() uint64 { return aging.counter },
+		},
+		{
+			name:    "PairingHeap",
+			enqueue: func(item int, priority float64) { pairing.Enqueue(item, priority) },
+			dequeue: pairing.Dequeue,
+			counter: func() uint64 { return pairing.counter },
+		},
+		{
+			name:    "LeftistHeap",
+			enqueue: func(item int, priority float64) { leftist.Enqueue(item, priority) },
+			dequeue: leftist.Dequeue,
+			counter: func() uint64 { return leftist.counter },
+		},
+		{
+			name:    "IndexedMinPriorityQueue",
+			min:     true,
+			enqueue: func(item int, priority float64) { indexed.Enqueue(item, priority) },
+			dequeue: func() interface{} {
+				key, _, err := indexed.Dequeue()
+				if err != nil {
+					return nil
+				}
+				return key
+			},
+			counter: func() uint64 { return indexed.counter },
+		},
+		{
+			name:    "IntIndexedMinPriorityQueue",
+			min:     true,
+			enqueue: func(item int, priority float64) { intIndexed.Enqueue(item, priority) },
+			dequeue: func() interface{} {
+				key, _, err := intIndexed.Dequeue()
+				if err != nil {
+					return nil
+				}
+				return key
+			},
+			counter: func() uint64 { return intIndexed.counter },
+		},
+		{
+			name:     "RadixHeap",
+			min:      true,
+			monotone: true,
+			enqueue:  func(item int, priority float64) { radix.Enqueue(item, uint64(priority)) },
+			dequeue:  radix.Dequeue,
+			counter:  func() uint64 { return radix.counter },
+		},
+		{
+			name:    "ImmutablePriorityQueue",
+			enqueue: func(item int, priority float64) { immutable = immutable.Enqueue(item, priority) },
+			dequeue: func() interface{} {
+				var value interface{}
+				value, immutable = immutable.Dequeue()
+				return value
+			},
+			counter: func() uint64 { return immutable.counter },
+		},
+	}
+}
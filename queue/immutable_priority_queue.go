@@ -0,0 +1,162 @@
+package gost
+
+/*
+ImmutablePriorityQueue is a persistent priority queue backed by a leftist heap. Enqueuing and de-queuing do not
+modify a queue; they return a new version of it instead, which shares all the nodes off the modified path with the
+original one. Forking a queue is therefore free, as opposed to cloning a PriorityQueue. It allows:
+
+- Enqueuing: obtaining a new queue with an item added, in worst-case O(log n).
+
+- De-queuing: obtaining the item with the highest priority (lowest, for min. queues) along with the queue holding
+the rest of items, in worst-case O(log n).
+
+- Melding: obtaining a new queue holding the items of two queues, in worst-case O(log n).
+
+This implementation uses FIFO order as tiebreaker when items have the same priority. When melding queues, items
+with equal priority coming from different queues are ordered by their insertion counters, which only guarantee
+FIFO order among items of the same queue. A nil *ImmutablePriorityQueue is a valid empty max. priority queue.
+*/
+type ImmutablePriorityQueue struct {
+	root    *immutableHeapNode
+	size    int
+	counter uint64 // counter ensures FIFO when priority between elements is equal
+	min     bool
+}
+
+// immutableHeapNode is a node of a persistent leftist heap. Nodes are never modified once built.
+type immutableHeapNode struct {
+	value    interface{}
+	priority float64
+	counter  uint64
+	rank     int // null path length of the node
+	left     *immutableHeapNode
+	right    *immutableHeapNode
+}
+
+// NewImmutablePriorityQueue returns an empty ImmutablePriorityQueue dequeuing the highest priorities first.
+func NewImmutablePriorityQueue() *ImmutablePriorityQueue {
+	return new(ImmutablePriorityQueue)
+}
+
+// NewImmutableMinPriorityQueue returns an empty ImmutablePriorityQueue dequeuing the lowest priorities first.
+func NewImmutableMinPriorityQueue() *ImmutablePriorityQueue {
+	return &ImmutablePriorityQueue{min: true}
+}
+
+// Enqueue returns a new queue with an interface item and its priority added to the current one.
+func (pq *ImmutablePriorityQueue) Enqueue(item interface{}, priority float64) *ImmutablePriorityQueue {
+	if pq == nil {
+		pq = new(ImmutablePriorityQueue)
+	}
+	root, counter := pq.root, pq.counter
+	if counter >= maxCounter {
+		root, counter = renormalizeImmutableHeap(root)
+	}
+	node := &immutableHeapNode{value: item, priority: priority, counter: counter, rank: 1}
+	return &ImmutablePriorityQueue{root: pq.merge(root, node), size: pq.size + 1, counter: counter + 1, min: pq.min}
+}
+
+// Dequeue returns the item with the highest priority (lowest, for min. queues), or insertion order when there's
+// no higher priority contents, along with the queue holding the rest of items. Returns nil and the queue itself if
+// empty, so that an empty min. queue is not turned into a (nil) max. one.
+func (pq *ImmutablePriorityQueue) Dequeue() (interface{}, *ImmutablePriorityQueue) {
+	if pq.Size() == 0 {
+		return nil, pq
+	}
+	rest := &ImmutablePriorityQueue{root: pq.merge(pq.root.left, pq.root.right), size: pq.size - 1, counter: pq.counter, min: pq.min}
+	if rest.size == 0 {
+		rest.counter = 0 // reset FIFO ordering counter once drained
+	}
+	return pq.root.value, rest
+}
+
+// Peek returns the item Dequeue() would return (nil if empty).
+func (pq *ImmutablePriorityQueue) Peek() interface{} {
+	if pq.Size() == 0 {
+		return nil
+	}
+	return pq.root.value
+}
+
+// Meld returns a new queue holding the items of both the current queue and other, which must dequeue in the same
+// order (both max. or both min. queues).
+func (pq *ImmutablePriorityQueue) Meld(other *ImmutablePriorityQueue) *ImmutablePriorityQueue {
+	if other.Size() == 0 {
+		return pq
+	}
+	if pq.Size() == 0 {
+		return other
+	}
+	counter := pq.counter
+	if other.counter > counter {
+		counter = other.counter
+	}
+	return &ImmutablePriorityQueue{root: pq.merge(pq.root, other.root), size: pq.size + other.size, counter: counter, min: pq.min}
+}
+
+// Size returns the size of the ImmutablePriorityQueue.
+func (pq *ImmutablePriorityQueue) Size() int {
+	if pq == nil {
+		return 0
+	}
+	return pq.size
+}
+
+// Internal function responding whether a should be dequeued before b: FIFO order on equal priorities.
+func (pq *ImmutablePriorityQueue) before(a, b *immutableHeapNode) bool {
+	if a.priority == b.priority {
+		return a.counter < b.counter
+	}
+	return (a.priority > b.priority) != pq.min
+}
+
+// Internal function which merges two leftist heaps along their right spines, copying the nodes on them.
+func (pq *ImmutablePriorityQueue) merge(a, b *immutableHeapNode) *immutableHeapNode {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	if pq.before(b, a) {
+		a, b = b, a
+	}
+	left, right := a.left, pq.merge(a.right, b)
+	if immutableRankOf(left) < immutableRankOf(right) {
+		left, right = right, left
+	}
+	return &immutableHeapNode{
+		value:    a.value,
+		priority: a.priority,
+		counter:  a.counter,
+		rank:     immutableRankOf(right) + 1,
+		left:     left,
+		right:    right,
+	}
+}
+
+// Internal function returning the rank of node, zero for nil nodes.
+func immutableRankOf(node *immutableHeapNode) int {
+	if node == nil {
+		return 0
+	}
+	return node.rank
+}
+
+// Internal function returning a copy of the heap rooted at root with renormalized counters, as the original nodes
+// may be shared with other versions. Returns the next counter to be assigned as well.
+func renormalizeImmutableHeap(root *immutableHeapNode) (*immutableHeapNode, uint64) {
+	var counters []*uint64
+	var clone func(node *immutableHeapNode) *immutableHeapNode
+	clone = func(node *immutableHeapNode) *immutableHeapNode {
+		if node == nil {
+			return nil
+		}
+		copied := *node
+		copied.left, copied.right = clone(node.left), clone(node.right)
+		counters = append(counters, &copied.counter)
+		return &copied
+	}
+	root = clone(root)
+	return root, renormalizeCounters(counters)
+}
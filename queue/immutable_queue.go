@@ -0,0 +1,118 @@
+package gost
+
+import (
+	"sync"
+
+	"github.com/christat/gost/list"
+)
+
+/*
+ImmutableQueue is a persistent FIFO queue, implemented as Okasaki's real-time queue. Enqueuing and de-queuing do
+not modify a queue; they return a new version of it instead, sharing most of its structure with the original one.
+It allows:
+
+- Enqueuing: obtaining a new queue with an element added at its tail, in worst-case O(1).
+
+- De-queuing: obtaining the element at the head of the queue along with the queue behind it, in worst-case O(1).
+
+- Peeking: obtaining the element at the head of the queue.
+
+Bounds hold no matter which versions are used, so every version can be kept around as a snapshot. The queue is
+made of a lazily evaluated front and a rear list of gost.Nodes, which is reversed into the front one step per
+operation. A nil *ImmutableQueue is a valid empty queue.
+
+Lazy parts are evaluated at most once and in a synchronized way, so versions may be shared between goroutines.
+*/
+type ImmutableQueue struct {
+	front    *stream    // elements from the head onwards
+	rear     *gost.Node // elements from the tail backwards, yet to be moved to front
+	schedule *stream    // suffix of front not evaluated yet; it is empty when rear must be rotated into front
+	size     int
+}
+
+// Enqueue returns a new queue with data (interface{}) added at the tail of the current one.
+func (queue *ImmutableQueue) Enqueue(data interface{}) *ImmutableQueue {
+	if queue == nil {
+		queue = new(ImmutableQueue)
+	}
+	return execute(queue.front, &gost.Node{Data: data, Next: queue.rear}, queue.schedule, queue.size+1)
+}
+
+// Dequeue returns the data at the head of the queue and the queue behind it. Returns nil and the queue itself if
+// empty.
+func (queue *ImmutableQueue) Dequeue() (interface{}, *ImmutableQueue) {
+	if queue.Size() == 0 {
+		return nil, queue
+	}
+	head := queue.front.force()
+	if queue.size == 1 {
+		return head.data, nil
+	}
+	return head.data, execute(head.next, queue.rear, queue.schedule, queue.size-1)
+}
+
+// Peek at the content of the queue head (nil if empty).
+func (queue *ImmutableQueue) Peek() interface{} {
+	if queue.Size() == 0 {
+		return nil
+	}
+	return queue.front.force().data
+}
+
+// Size returns the size of the ImmutableQueue.
+func (queue *ImmutableQueue) Size() int {
+	if queue == nil {
+		return 0
+	}
+	return queue.size
+}
+
+// Internal function which evaluates one more element of the front stream, or starts rotating rear into front once
+// it has been fully evaluated.
+func execute(front *stream, rear *gost.Node, schedule *stream, size int) *ImmutableQueue {
+	if cell := schedule.force(); cell != nil {
+		return &ImmutableQueue{front: front, rear: rear, schedule: cell.next, size: size}
+	}
+	front = rotate(front, rear, nil)
+	return &ImmutableQueue{front: front, schedule: front, size: size}
+}
+
+// Internal function returning the lazy stream front ++ reverse(rear) ++ accumulated, where rear holds exactly one
+// element more than front. Each evaluated element moves one element of rear onto accumulated.
+func rotate(front *stream, rear *gost.Node, accumulated *stream) *stream {
+	return &stream{thunk: func() *streamCell {
+		cell := front.force()
+		if cell == nil {
+			return &streamCell{data: rear.Data, next: accumulated}
+		}
+		next := &stream{cell: &streamCell{data: rear.Data, next: accumulated}, evaluated: true}
+		return &streamCell{data: cell.data, next: rotate(cell.next, rear.Next, next)}
+	}}
+}
+
+// stream is a lazily evaluated, memoized list. A nil stream is empty.
+type stream struct {
+	once      sync.Once
+	thunk     func() *streamCell // computes cell; dropped once evaluated
+	cell      *streamCell        // nil if the stream is empty
+	evaluated bool
+}
+
+// streamCell holds an element of a stream and the rest of it.
+type streamCell struct {
+	data interface{}
+	next *stream
+}
+
+// Internal function which evaluates the stream, at most once. Returns its first cell, or nil if empty.
+func (s *stream) force() *streamCell {
+	if s == nil {
+		return nil
+	}
+	if !s.evaluated {
+		s.once.Do(func() {
+			s.cell, s.thunk = s.thunk(), nil
+		})
+	}
+	return s.cell
+}
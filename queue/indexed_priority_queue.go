@@ -0,0 +1,254 @@
+package gost
+
+import (
+	"container/heap"
+	"errors"
+)
+
+/*
+IndexedMinPriorityQueue is a heap-based min. priority queue whose items are identified by a key of comparable type
+K (e.g. a vertex ID), targeted at graph algorithms such as Dijkstra's or Prim's. Callers address items by key
+instead of holding handles. It allows:
+
+- Enqueuing/Upserting: inserting a key with its priority, or updating the priority of an existing one.
+
+- Decreasing keys: lowering the priority of a key in O(log n).
+
+- Querying: checking whether a key is queued and obtaining its priority in O(1).
+
+- De-queuing: retrieving the key with the lowest priority, or removing an arbitrary key.
+
+This implementation uses FIFO order as tiebreaker when elements have the same priority; updating the priority
+of a key keeps its original place in that order. Keys are stored in a map: if K is an interface type, keys whose
+dynamic type is not comparable (e.g. slices) make the map operations panic, as they would for any Go map.
+
+Note that the implementation is NOT thread-safe.
+*/
+type IndexedMinPriorityQueue[K comparable] struct {
+	indexedHeap
+	items map[K]*priorityItem
+}
+
+// NewIndexedMinPriorityQueue initializes the indexed priority queue and returns the instance.
+func NewIndexedMinPriorityQueue[K comparable]() *IndexedMinPriorityQueue[K] {
+	return &IndexedMinPriorityQueue[K]{items: make(map[K]*priorityItem)}
+}
+
+// Enqueue adds a key and its priority into the queue. Returns an error if the key is already queued.
+func (pq *IndexedMinPriorityQueue[K]) Enqueue(key K, priority float64) error {
+	if pq.Contains(key) {
+		return errors.New("cannot Enqueue() key already queued")
+	}
+	pq.Upsert(key, priority)
+	return nil
+}
+
+// Upsert sets the priority of key, enqueuing it if it was not queued yet.
+func (pq *IndexedMinPriorityQueue[K]) Upsert(key K, priority float64) {
+	if item, ok := pq.items[key]; ok {
+		pq.update(item, priority)
+		return
+	}
+	if pq.items == nil {
+		pq.items = make(map[K]*priorityItem)
+	}
+	pq.items[key] = pq.push(key, priority)
+}
+
+// DecreaseKey lowers the priority of key. Returns an error if the key is not queued or priority is higher than its current one.
+func (pq *IndexedMinPriorityQueue[K]) DecreaseKey(key K, priority float64) error {
+	item, ok := pq.items[key]
+	if !ok {
+		return errors.New("cannot DecreaseKey() key not queued")
+	}
+	if priority > item.priority {
+		return errors.New("cannot DecreaseKey() priority higher than current one")
+	}
+	pq.update(item, priority)
+	return nil
+}
+
+// Contains responds whether key is currently queued.
+func (pq *IndexedMinPriorityQueue[K]) Contains(key K) bool {
+	_, ok := pq.items[key]
+	return ok
+}
+
+// PriorityOf returns the priority of key. Returns an error if the key is not queued.
+func (pq *IndexedMinPriorityQueue[K]) PriorityOf(key K) (float64, error) {
+	item, ok := pq.items[key]
+	if !ok {
+		return 0, errors.New("cannot PriorityOf() key not queued")
+	}
+	return item.priority, nil
+}
+
+// Dequeue removes the key with the lowest priority, or insertion order when there's no lower priority contents.
+// Returns the key and its priority, or an error if the queue is empty.
+func (pq *IndexedMinPriorityQueue[K]) Dequeue() (K, float64, error) {
+	if pq.Size() == 0 {
+		var zero K
+		return zero, 0, errors.New("cannot Dequeue() empty queue")
+	}
+	item := pq.pop()
+	key := item.value.(K)
+	delete(pq.items, key)
+	return key, item.priority, nil
+}
+
+// Remove deletes key from the queue, returning its priority. Returns an error if the key is not queued.
+func (pq *IndexedMinPriorityQueue[K]) Remove(key K) (float64, error) {
+	item, ok := pq.items[key]
+	if !ok {
+		return 0, errors.New("cannot Remove() key not queued")
+	}
+	pq.remove(item)
+	delete(pq.items, key)
+	return item.priority, nil
+}
+
+/*
+IntIndexedMinPriorityQueue is the counterpart of IndexedMinPriorityQueue for dense integer keys in the range
+[0, capacity), such as vertex IDs of a graph. Keys are indexed through a slice rather than a map, so that lookups
+avoid hashing. It allows the same operations as IndexedMinPriorityQueue, which return an error for keys out of
+bounds.
+
+Note that the implementation is NOT thread-safe.
+*/
+type IntIndexedMinPriorityQueue struct {
+	indexedHeap
+	items []*priorityItem // items[key] is nil when key is not queued
+}
+
+// NewIntIndexedMinPriorityQueue initializes an indexed priority queue accepting keys in [0, capacity).
+func NewIntIndexedMinPriorityQueue(capacity int) *IntIndexedMinPriorityQueue {
+	return &IntIndexedMinPriorityQueue{items: make([]*priorityItem, capacity)}
+}
+
+// Enqueue adds a key and its priority into the queue. Returns an error if the key is out of bounds or already queued.
+func (pq *IntIndexedMinPriorityQueue) Enqueue(key int, priority float64) error {
+	if key < 0 || key >= len(pq.items) {
+		return errors.New("cannot Enqueue() key out of bounds")
+	}
+	if pq.items[key] != nil {
+		return errors.New("cannot Enqueue() key already queued")
+	}
+	pq.items[key] = pq.push(key, priority)
+	return nil
+}
+
+// Upsert sets the priority of key, enqueuing it if it was not queued yet. Returns an error if the key is out of bounds.
+func (pq *IntIndexedMinPriorityQueue) Upsert(key int, priority float64) error {
+	if key < 0 || key >= len(pq.items) {
+		return errors.New("cannot Upsert() key out of bounds")
+	}
+	if item := pq.items[key]; item != nil {
+		pq.update(item, priority)
+		return nil
+	}
+	pq.items[key] = pq.push(key, priority)
+	return nil
+}
+
+// DecreaseKey lowers the priority of key. Returns an error if the key is not queued or priority is higher than its current one.
+func (pq *IntIndexedMinPriorityQueue) DecreaseKey(key int, priority float64) error {
+	if !pq.Contains(key) {
+		return errors.New("cannot DecreaseKey() key not queued")
+	}
+	item := pq.items[key]
+	if priority > item.priority {
+		return errors.New("cannot DecreaseKey() priority higher than current one")
+	}
+	pq.update(item, priority)
+	return nil
+}
+
+// Contains responds whether key is currently queued.
+func (pq *IntIndexedMinPriorityQueue) Contains(key int) bool {
+	return key >= 0 && key < len(pq.items) && pq.items[key] != nil
+}
+
+// PriorityOf returns the priority of key. Returns an error if the key is not queued.
+func (pq *IntIndexedMinPriorityQueue) PriorityOf(key int) (float64, error) {
+	if !pq.Contains(key) {
+		return 0, errors.New("cannot PriorityOf() key not queued")
+	}
+	return pq.items[key].priority, nil
+}
+
+// Dequeue removes the key with the lowest priority, or insertion order when there's no lower priority contents.
+// Returns the key and its priority, or an error if the queue is empty.
+func (pq *IntIndexedMinPriorityQueue) Dequeue() (int, float64, error) {
+	if pq.Size() == 0 {
+		return 0, 0, errors.New("cannot Dequeue() empty queue")
+	}
+	item := pq.pop()
+	key := item.value.(int)
+	pq.items[key] = nil
+	return key, item.priority, nil
+}
+
+// Remove deletes key from the queue, returning its priority. Returns an error if the key is not queued.
+func (pq *IntIndexedMinPriorityQueue) Remove(key int) (float64, error) {
+	if !pq.Contains(key) {
+		return 0, errors.New("cannot Remove() key not queued")
+	}
+	item := pq.items[key]
+	pq.remove(item)
+	pq.items[key] = nil
+	return item.priority, nil
+}
+
+/*
+	The type defined below wraps MinHeapContents, relying on the indices kept up to date by its heap.Interface
+	methods to locate items in O(1). Indexed queues are intended to abstract the underlying implementation details.
+*/
+
+// indexedHeap holds the priorityItems of an indexed queue, whose values are the keys of the queue.
+type indexedHeap struct {
+	contents MinHeapContents
+	counter  uint64 // counter ensures FIFO when priority between elements is equal
+}
+
+// Size returns the size of the indexed queue.
+func (ih *indexedHeap) Size() int {
+	return ih.contents.Len()
+}
+
+// Internal function which adds key with priority to the heap, returning its priorityItem.
+func (ih *indexedHeap) push(key interface{}, priority float64) *priorityItem {
+	if ih.counter >= maxCounter {
+		ih.counter = renormalizeCounters(priorityItemCounters(ih.contents))
+	}
+	item := newPriorityItem(key, priority, ih.counter)
+	ih.counter++
+	heap.Push(&ih.contents, item)
+	return item
+}
+
+// Internal function which changes the priority of item, restoring its position in the heap.
+func (ih *indexedHeap) update(item *priorityItem, priority float64) {
+	item.priority = priority
+	heap.Fix(&ih.contents, item.index)
+}
+
+// Internal function which removes and returns the priorityItem at the root of the heap.
+func (ih *indexedHeap) pop() *priorityItem {
+	item := ih.contents[0]
+	heap.Pop(&ih.contents)
+	ih.resetIfDrained()
+	return item
+}
+
+// Internal function which removes item from the heap, wherever it is.
+func (ih *indexedHeap) remove(item *priorityItem) {
+	heap.Remove(&ih.contents, item.index)
+	ih.resetIfDrained()
+}
+
+// Internal function which resets the FIFO ordering counter once the heap is drained.
+func (ih *indexedHeap) resetIfDrained() {
+	if ih.contents.Len() == 0 {
+		ih.counter = 0
+	}
+}
@@ -0,0 +1,40 @@
+package gost
+
+import (
+	"github.com/christat/gost/list"
+)
+
+// Iterator returns a gost.Iterator over the queue contents, from head to tail, without removing them.
+func (queue *NodeQueue) Iterator() gost.Iterator {
+	return gost.NewNodeIterator(queue.head)
+}
+
+// Iterator returns a gost.Iterator over the queue contents, from head to tail, without removing them.
+func (queue *SliceQueue) Iterator() gost.Iterator {
+	return gost.NewSliceIterator(queue.slice)
+}
+
+// Drain returns a gost.Iterator which dequeues every item of queue as it is reached, leaving it empty at the end.
+// It works with any Queue implementation.
+func Drain(queue Queue) gost.Iterator {
+	return &drainIterator{queue: queue}
+}
+
+// drainIterator dequeues the items of a Queue.
+type drainIterator struct {
+	queue Queue
+	value interface{}
+}
+
+func (it *drainIterator) Next() bool {
+	if it.queue.Size() == 0 {
+		it.value = nil
+		return false
+	}
+	it.value = it.queue.Dequeue()
+	return true
+}
+
+func (it *drainIterator) Value() interface{} {
+	return it.value
+}
@@ -0,0 +1,151 @@
+package gost
+
+import "errors"
+
+/*
+LeftistHeap is a mergeable priority queue backed by a leftist heap. It exposes the same Enqueue() and Dequeue()
+methods as PriorityQueue (highest priority first, FIFO order as tiebreaker), and additionally allows:
+
+- Melding: absorbing all the items of another LeftistHeap in O(log n).
+
+- Updating: changing the priority of an enqueued item through its Handle in O(log n).
+
+All operations are worst-case O(log n), as opposed to the amortized bounds of PairingHeap. When melding heaps,
+items with equal priority coming from different heaps are ordered by their insertion counters, which only
+guarantee FIFO order among items of the same heap.
+
+Note that the implementation is NOT thread-safe.
+*/
+type LeftistHeap struct {
+	root    *Handle
+	size    int
+	counter uint64 // counter ensures FIFO when priority between elements is equal
+}
+
+// NewLeftistHeap initializes an empty LeftistHeap and returns the instance.
+func NewLeftistHeap() *LeftistHeap {
+	return new(LeftistHeap)
+}
+
+// Enqueue adds an interface item and its priority into the LeftistHeap. Returns the Handle of the item.
+func (lh *LeftistHeap) Enqueue(item interface{}, priority float64) *Handle {
+	if lh.counter >= maxCounter {
+		lh.counter = renormalizeCounters(handleCounters(lh.root))
+	}
+	node := &Handle{value: item, priority: priority, counter: lh.counter, queued: true, rank: 1}
+	lh.counter++
+	lh.root = mergeLeftist(lh.root, node)
+	lh.size++
+	return node
+}
+
+// Dequeue removes the item in the LeftistHeap with the highest priority, or insertion order when there's no
+// higher priority contents. If the heap is empty, returns nil.
+func (lh *LeftistHeap) Dequeue() interface{} {
+	if lh.size == 0 {
+		return nil
+	}
+	node := lh.root
+	lh.detach(node)
+	node.queued = false
+	lh.size--
+	if lh.size == 0 {
+		lh.counter = 0 // reset FIFO ordering counter once drained
+	}
+	return node.value
+}
+
+// Peek returns the item Dequeue() would remove (nil if empty) without removing it.
+func (lh *LeftistHeap) Peek() interface{} {
+	if lh.size == 0 {
+		return nil
+	}
+	return lh.root.value
+}
+
+// Meld moves all the items of other into the LeftistHeap, leaving other empty. Handles of other remain valid.
+func (lh *LeftistHeap) Meld(other *LeftistHeap) {
+	if other == lh || other.size == 0 {
+		return
+	}
+	lh.root = mergeLeftist(lh.root, other.root)
+	lh.size += other.size
+	if other.counter > lh.counter {
+		lh.counter = other.counter
+	}
+	other.root, other.size, other.counter = nil, 0, 0
+}
+
+// UpdatePriority sets the priority of the item referenced by handle. Returns an error if it was already dequeued.
+func (lh *LeftistHeap) UpdatePriority(handle *Handle, priority float64) error {
+	if !handle.queued {
+		return errors.New("cannot UpdatePriority() handle no longer queued")
+	}
+	lh.detach(handle)
+	handle.priority = priority
+	lh.root = mergeLeftist(lh.root, handle)
+	return nil
+}
+
+// Size returns the size of the LeftistHeap.
+func (lh *LeftistHeap) Size() int {
+	return lh.size
+}
+
+// Internal function which removes node from the heap, replacing it by the merge of its children.
+// Ranks are then restored upwards, stopping as soon as an ancestor's rank remains unchanged.
+func (lh *LeftistHeap) detach(node *Handle) {
+	parent := node.parent
+	merged := mergeLeftist(node.left, node.right)
+	if merged != nil {
+		merged.parent = parent
+	}
+	node.left, node.right, node.parent, node.rank = nil, nil, nil, 1
+	if parent == nil {
+		lh.root = merged
+		return
+	}
+	if parent.left == node {
+		parent.left = merged
+	} else {
+		parent.right = merged
+	}
+	for ; parent != nil; parent = parent.parent {
+		if rankOf(parent.left) < rankOf(parent.right) {
+			parent.left, parent.right = parent.right, parent.left
+		}
+		rank := rankOf(parent.right) + 1
+		if rank == parent.rank {
+			return
+		}
+		parent.rank = rank
+	}
+}
+
+// Internal function returning the rank of node, zero for nil nodes.
+func rankOf(node *Handle) int {
+	if node == nil {
+		return 0
+	}
+	return node.rank
+}
+
+// Internal function which merges two leftist trees along their right spines, returning the resulting root.
+func mergeLeftist(a, b *Handle) *Handle {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	if b.before(a) {
+		a, b = b, a
+	}
+	a.right = mergeLeftist(a.right, b)
+	a.right.parent = a
+	if rankOf(a.left) < rankOf(a.right) {
+		a.left, a.right = a.right, a.left
+	}
+	a.rank = rankOf(a.right) + 1
+	return a
+}
@@ -0,0 +1,56 @@
+package gost
+
+/*
+Handle references an item enqueued into a PairingHeap or a LeftistHeap. It is returned by Enqueue() and allows
+updating the priority of the item later on, without searching for it.
+
+A Handle belongs to the heap it was obtained from (or to the heap it was melded into); using it with any
+other heap leads to undefined behaviour.
+*/
+type Handle struct {
+	value    interface{}
+	priority float64
+	counter  uint64 // counter ensures FIFO when priority between elements is equal
+	queued   bool   // whether the item is still held by its heap
+
+	// Links of the heap tree. PairingHeap uses left as first child, right as next sibling and parent as previous
+	// sibling (or parent, for first children), whereas LeftistHeap uses them as plain binary tree links.
+	left   *Handle
+	right  *Handle
+	parent *Handle
+	rank   int // null path length of the node, only used by LeftistHeap
+}
+
+// Value returns the item referenced by the Handle.
+func (h *Handle) Value() interface{} {
+	return h.value
+}
+
+// Priority returns the current priority of the item referenced by the Handle.
+func (h *Handle) Priority() float64 {
+	return h.priority
+}
+
+// Internal function responding whether h should be dequeued before other: higher priority first, FIFO on ties.
+func (h *Handle) before(other *Handle) bool {
+	if h.priority == other.priority {
+		return h.counter < other.counter
+	}
+	return h.priority > other.priority
+}
+
+// Internal function returning references to the counters of every node in the tree rooted at root.
+func handleCounters(root *Handle) []*uint64 {
+	var counters []*uint64
+	pending := []*Handle{root}
+	for len(pending) > 0 {
+		node := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+		if node == nil {
+			continue
+		}
+		counters = append(counters, &node.counter)
+		pending = append(pending, node.left, node.right)
+	}
+	return counters
+}
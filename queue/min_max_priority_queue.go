@@ -0,0 +1,203 @@
+package gost
+
+/*
+MinMaxPriorityQueue is a double-ended priority queue backed by a min-max heap. Both the highest and the
+lowest priority items can be inspected in O(1) and removed in O(log n), which makes it suitable for bounded
+queues serving the best item while evicting the worst one. It allows:
+
+- Enqueuing: inserting an item along with its priority.
+
+- De-queuing: retrieving either the item with the highest priority (DequeueMax) or the lowest one (DequeueMin).
+
+- Peeking: obtaining either end of the queue without removing it.
+
+Items with equal priority are kept in FIFO order at both ends, just like PriorityQueue and MinPriorityQueue.
+To achieve that, the heap orders distinct priorities, each of them holding a NodeQueue of its items.
+
+Note that the implementation is NOT thread-safe.
+*/
+type MinMaxPriorityQueue struct {
+	heap    []*priorityBucket
+	buckets map[float64]*priorityBucket
+	size    int
+}
+
+// priorityBucket groups all the items sharing a priority, in insertion order.
+type priorityBucket struct {
+	priority float64
+	items    NodeQueue
+}
+
+// NewMinMaxPriorityQueue initializes the min-max heap based priority queue and returns the instance.
+func NewMinMaxPriorityQueue() *MinMaxPriorityQueue {
+	return &MinMaxPriorityQueue{buckets: make(map[float64]*priorityBucket)}
+}
+
+// Enqueue adds an interface item and its priority into the MinMaxPriorityQueue.
+func (pq *MinMaxPriorityQueue) Enqueue(item interface{}, priority float64) {
+	if pq.buckets == nil {
+		pq.buckets = make(map[float64]*priorityBucket)
+	}
+	bucket, ok := pq.buckets[priority]
+	if !ok {
+		bucket = &priorityBucket{priority: priority}
+		pq.buckets[priority] = bucket
+		pq.heap = append(pq.heap, bucket)
+		pq.pushUp(len(pq.heap) - 1)
+	}
+	bucket.items.Enqueue(item)
+	pq.size++
+}
+
+// DequeueMax removes the item with the highest priority, or insertion order when priorities are equal.
+// If the queue is empty, returns nil.
+func (pq *MinMaxPriorityQueue) DequeueMax() interface{} {
+	if pq.size == 0 {
+		return nil
+	}
+	return pq.dequeue(pq.maxIndex())
+}
+
+// DequeueMin removes the item with the lowest priority, or insertion order when priorities are equal.
+// If the queue is empty, returns nil.
+func (pq *MinMaxPriorityQueue) DequeueMin() interface{} {
+	if pq.size == 0 {
+		return nil
+	}
+	return pq.dequeue(0)
+}
+
+// PeekMax returns the item DequeueMax would remove (nil if empty) without removing it.
+func (pq *MinMaxPriorityQueue) PeekMax() interface{} {
+	if pq.size == 0 {
+		return nil
+	}
+	return pq.heap[pq.maxIndex()].items.head.Data
+}
+
+// PeekMin returns the item DequeueMin would remove (nil if empty) without removing it.
+func (pq *MinMaxPriorityQueue) PeekMin() interface{} {
+	if pq.size == 0 {
+		return nil
+	}
+	return pq.heap[0].items.head.Data
+}
+
+// Size returns the size of the MinMaxPriorityQueue.
+func (pq *MinMaxPriorityQueue) Size() int {
+	return pq.size
+}
+
+// Internal function which removes the oldest item of the bucket at index i, dropping the bucket once empty.
+func (pq *MinMaxPriorityQueue) dequeue(i int) interface{} {
+	bucket := pq.heap[i]
+	item := bucket.items.Dequeue()
+	pq.size--
+	if bucket.items.Size() == 0 {
+		delete(pq.buckets, bucket.priority)
+		last := len(pq.heap) - 1
+		pq.heap[i] = pq.heap[last]
+		pq.heap[last] = nil
+		pq.heap = pq.heap[:last]
+		if i < last {
+			pq.pushDown(i)
+		}
+	}
+	return item
+}
+
+/*
+	The methods defined below maintain the min-max heap invariants: buckets on even levels (starting with the root)
+	have a lower priority than all of their descendants, whereas buckets on odd levels have a higher one.
+*/
+
+// Internal function returning the index of the bucket with the highest priority. Expects a non-empty heap.
+func (pq *MinMaxPriorityQueue) maxIndex() int {
+	switch len(pq.heap) {
+	case 1:
+		return 0
+	case 2:
+		return 1
+	}
+	if pq.heap[1].priority > pq.heap[2].priority {
+		return 1
+	}
+	return 2
+}
+
+// Internal function responding whether index i lies on a min (even) level of the heap.
+func isMinLevel(i int) bool {
+	level := 0
+	for i > 0 {
+		i = (i - 1) / 2
+		level++
+	}
+	return level%2 == 0
+}
+
+// Internal function comparing the priorities of the buckets at i and j, in ascending order if min is true.
+func (pq *MinMaxPriorityQueue) before(i, j int, min bool) bool {
+	if min {
+		return pq.heap[i].priority < pq.heap[j].priority
+	}
+	return pq.heap[i].priority > pq.heap[j].priority
+}
+
+// Internal function which swaps the buckets at i and j.
+func (pq *MinMaxPriorityQueue) swap(i, j int) {
+	pq.heap[i], pq.heap[j] = pq.heap[j], pq.heap[i]
+}
+
+// Internal function which moves the bucket at i up to its place after being appended.
+func (pq *MinMaxPriorityQueue) pushUp(i int) {
+	if i == 0 {
+		return
+	}
+	parent := (i - 1) / 2
+	min := isMinLevel(i)
+	if pq.before(parent, i, min) {
+		// The bucket belongs to the levels of the opposite kind, which its parent is part of.
+		pq.swap(i, parent)
+		pq.pushUpLevels(parent, !min)
+	} else {
+		pq.pushUpLevels(i, min)
+	}
+}
+
+// Internal function which moves the bucket at i up through its grandparents, all of them on levels of the same kind.
+func (pq *MinMaxPriorityQueue) pushUpLevels(i int, min bool) {
+	for i > 2 {
+		grandparent := ((i-1)/2 - 1) / 2
+		if !pq.before(i, grandparent, min) {
+			return
+		}
+		pq.swap(i, grandparent)
+		i = grandparent
+	}
+}
+
+// Internal function which moves the bucket at i down to its place after replacing a removed one.
+func (pq *MinMaxPriorityQueue) pushDown(i int) {
+	min := isMinLevel(i)
+	for {
+		// Find the first (in the order of the level of i) among the children and grandchildren of i.
+		first := -1
+		for _, candidate := range []int{2*i + 1, 2*i + 2, 4*i + 3, 4*i + 4, 4*i + 5, 4*i + 6} {
+			if candidate < len(pq.heap) && (first == -1 || pq.before(candidate, first, min)) {
+				first = candidate
+			}
+		}
+		if first == -1 || !pq.before(first, i, min) {
+			return
+		}
+		pq.swap(first, i)
+		if first <= 2*i+2 {
+			// A child only comes first when it has no descendants of its own, so there is nothing left to check.
+			return
+		}
+		if parent := (first - 1) / 2; pq.before(parent, first, min) {
+			pq.swap(first, parent)
+		}
+		i = first
+	}
+}
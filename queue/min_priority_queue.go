@@ -1,13 +1,18 @@
 package gost
 
-import "container/heap"
+import (
+	"container/heap"
+	"errors"
+)
 
 // MinPriorityQueue implements a heap-based priority queue, only exposing methods Enqueue() and Dequeue() for simplicity.
 // Inverse priority means that items with lower priority are dequeued faster than higher priority ones.
 // This implementation uses FIFO order as tiebreaker when elements have the same priority.
+// Its backend is a binary heap built on container/heap, or a d-ary heap when created with NewMinPriorityQueueWithArity().
 type MinPriorityQueue struct {
 	contents MinHeapContents
-	counter  int // counter ensures FIFO when priority between elements is equal
+	counter  uint64             // counter ensures FIFO when priority between elements is equal
+	dary     *DaryPriorityQueue // d-ary heap backend replacing contents, if the queue was created with an arity
 }
 
 // NewMinPriorityQueue initializes the heap-based priority queue and returns the instance.
@@ -17,24 +22,101 @@ func NewMinPriorityQueue() (pq *MinPriorityQueue) {
 	return
 }
 
+// NewMinPriorityQueueWithArity initializes a MinPriorityQueue backed by a d-ary heap laid out as a struct of arrays (see
+// DaryPriorityQueue) instead of container/heap. arity is the amount of children per heap node; values lower than 2
+// default to 4.
+func NewMinPriorityQueueWithArity(arity int) *MinPriorityQueue {
+	return &MinPriorityQueue{dary: NewDaryMinPriorityQueue(arity)}
+}
+
+// NewMinPriorityQueueFrom initializes a MinPriorityQueue holding items, each of them with the priority at the same index.
+// The heap is built bottom-up in O(n). Returns an error if items and priorities differ in length.
+func NewMinPriorityQueueFrom(items []interface{}, priorities []float64) (*MinPriorityQueue, error) {
+	pq := NewMinPriorityQueue()
+	if err := pq.EnqueueAll(items, priorities); err != nil {
+		return nil, err
+	}
+	return pq, nil
+}
+
 // Enqueue adds an interface item and its priority into the MinPriorityQueue.
 func (pq *MinPriorityQueue) Enqueue(item interface{}, priority float64) {
-	heap.Push(&pq.contents, newPriorityItem(item, priority, pq.counter))
-	pq.counter++
+	if pq.dary != nil {
+		pq.dary.Enqueue(item, priority)
+		return
+	}
+	heap.Push(&pq.contents, newPriorityItem(item, priority, pq.nextCounter()))
+}
+
+// EnqueueAll adds items into the MinPriorityQueue, each of them with the priority at the same index. Items are
+// considered to be enqueued in slice order. Returns an error if items and priorities differ in length.
+func (pq *MinPriorityQueue) EnqueueAll(items []interface{}, priorities []float64) error {
+	if len(items) != len(priorities) {
+		return errors.New("cannot EnqueueAll() items and priorities differ in length")
+	}
+	if pq.dary != nil {
+		return pq.dary.EnqueueAll(items, priorities)
+	}
+	if len(items) < pq.Size() {
+		// Pushing a few items into a large heap is cheaper than rebuilding it.
+		for i, item := range items {
+			pq.Enqueue(item, priorities[i])
+		}
+		return nil
+	}
+	for i, item := range items {
+		pq.contents.Push(newPriorityItem(item, priorities[i], pq.nextCounter()))
+	}
+	heap.Init(&pq.contents)
+	return nil
 }
 
 // Dequeue removes the item in the MinPriorityQueue with the lowest priority, or insertion order when there's no lower priority contents.
 // If the queue is empty, returns nil.
 func (pq *MinPriorityQueue) Dequeue() interface{} {
+	if pq.dary != nil {
+		return pq.dary.Dequeue()
+	}
 	if pq.Size() == 0 {
-		pq.counter = 0 // reset FIFO ordering counter (opportunistic)
 		return nil
 	}
-	return heap.Pop(&pq.contents)
+	item := heap.Pop(&pq.contents)
+	if pq.Size() == 0 {
+		pq.counter = 0 // reset FIFO ordering counter once drained
+	}
+	return item
+}
+
+// DequeueN removes up to k items from the MinPriorityQueue, returning them in dequeuing order.
+func (pq *MinPriorityQueue) DequeueN(k int) []interface{} {
+	if k > pq.Size() {
+		k = pq.Size()
+	}
+	if k < 0 {
+		k = 0
+	}
+	items := make([]interface{}, 0, k)
+	for i := 0; i < k; i++ {
+		items = append(items, pq.Dequeue())
+	}
+	return items
+}
+
+// Internal function returning the counter for the next enqueued item, renormalizing counters when exhausted.
+func (pq *MinPriorityQueue) nextCounter() uint64 {
+	if pq.counter >= maxCounter {
+		pq.counter = renormalizeCounters(priorityItemCounters(pq.contents))
+	}
+	counter := pq.counter
+	pq.counter++
+	return counter
 }
 
 // Size returns the size of the MinPriorityQueue.
 func (pq *MinPriorityQueue) Size() int {
+	if pq.dary != nil {
+		return pq.dary.Size()
+	}
 	return pq.contents.Len()
 }
 
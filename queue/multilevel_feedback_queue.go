@@ -0,0 +1,129 @@
+package gost
+
+import (
+	"errors"
+	"time"
+)
+
+/*
+MultilevelFeedbackQueue is a multi-level feedback queue (MLFQ) scheduler. It keeps N priority levels, each of them
+backed by a Queue implementation and granted a time quantum, and allows:
+
+- Enqueuing: adding a new task at the highest priority level (level 0).
+
+- De-queuing: retrieving the next task from the highest non-empty level, along with its level and quantum.
+
+- Re-queuing: handing a task back after running it. Tasks which used up their whole quantum are demoted one level,
+whereas tasks which yielded earlier keep their level.
+
+- Boosting: moving every task back to the highest level, either on demand or periodically, so that long-running
+tasks are not starved by interactive ones. Periodic boosts are checked on Dequeue() against an injectable Clock.
+
+Note that the implementation is NOT thread-safe.
+*/
+type MultilevelFeedbackQueue struct {
+	levels    []Queue
+	quanta    []time.Duration
+	interval  time.Duration // time between periodic boosts; zero disables them
+	clock     Clock
+	lastBoost time.Time
+}
+
+// NewMultilevelFeedbackQueue initializes an MLFQ with one level per quantum, ordered from highest to lowest
+// priority. Each level is created by newQueue (a NodeQueue if nil). Tasks are boosted every interval (never if
+// zero), as measured by clock (the system clock if nil). Returns an error if quanta is empty.
+func NewMultilevelFeedbackQueue(quanta []time.Duration, interval time.Duration, newQueue func() Queue, clock Clock) (*MultilevelFeedbackQueue, error) {
+	if len(quanta) == 0 {
+		return nil, errors.New("cannot NewMultilevelFeedbackQueue() without levels")
+	}
+	if newQueue == nil {
+		newQueue = func() Queue { return new(NodeQueue) }
+	}
+	if clock == nil {
+		clock = systemClock{}
+	}
+	levels := make([]Queue, len(quanta))
+	for i := range levels {
+		levels[i] = newQueue()
+	}
+	return &MultilevelFeedbackQueue{
+		levels:    levels,
+		quanta:    append([]time.Duration(nil), quanta...),
+		interval:  interval,
+		clock:     clock,
+		lastBoost: clock.Now(),
+	}, nil
+}
+
+// Enqueue adds a new task at the highest priority level.
+func (mlfq *MultilevelFeedbackQueue) Enqueue(task interface{}) {
+	mlfq.levels[0].Enqueue(task)
+}
+
+// Dequeue removes the next task to run, returning it along with its level and the quantum it may use.
+// Boosts all tasks first if the boost interval has elapsed. If the MLFQ is empty, returns nil, -1 and 0.
+func (mlfq *MultilevelFeedbackQueue) Dequeue() (task interface{}, level int, quantum time.Duration) {
+	if mlfq.interval > 0 {
+		if now := mlfq.clock.Now(); now.Sub(mlfq.lastBoost) >= mlfq.interval {
+			mlfq.boost(now)
+		}
+	}
+	for i, queue := range mlfq.levels {
+		if queue.Size() > 0 {
+			return queue.Dequeue(), i, mlfq.quanta[i]
+		}
+	}
+	return nil, -1, 0
+}
+
+// Requeue hands back a task obtained from Dequeue() at level, after it ran for used. The task is demoted one level
+// if it used up its quantum (staying at the lowest level once there), or otherwise keeps its level.
+// Finished tasks must simply not be re-queued.
+func (mlfq *MultilevelFeedbackQueue) Requeue(task interface{}, level int, used time.Duration) {
+	if level < 0 {
+		level = 0
+	}
+	if level >= len(mlfq.levels) {
+		level = len(mlfq.levels) - 1
+	}
+	if used >= mlfq.quanta[level] && level < len(mlfq.levels)-1 {
+		level++
+	}
+	mlfq.levels[level].Enqueue(task)
+}
+
+// Boost moves every task to the highest priority level, preserving their order (by level first, then by arrival).
+func (mlfq *MultilevelFeedbackQueue) Boost() {
+	mlfq.boost(mlfq.clock.Now())
+}
+
+// Levels returns the amount of priority levels.
+func (mlfq *MultilevelFeedbackQueue) Levels() int {
+	return len(mlfq.levels)
+}
+
+// LevelSize returns the amount of tasks waiting at level. Returns 0 for unknown levels.
+func (mlfq *MultilevelFeedbackQueue) LevelSize(level int) int {
+	if level < 0 || level >= len(mlfq.levels) {
+		return 0
+	}
+	return mlfq.levels[level].Size()
+}
+
+// Size returns the amount of tasks waiting across all levels.
+func (mlfq *MultilevelFeedbackQueue) Size() (size int) {
+	for _, queue := range mlfq.levels {
+		size += queue.Size()
+	}
+	return
+}
+
+// Internal function which moves every task into the highest level and records now as the last boost.
+func (mlfq *MultilevelFeedbackQueue) boost(now time.Time) {
+	for _, queue := range mlfq.levels[1:] {
+		for queue.Size() > 0 {
+			mlfq.levels[0].Enqueue(queue.Dequeue())
+		}
+	}
+	mlfq.lastBoost = now
+}
@@ -0,0 +1,164 @@
+package gost
+
+import "errors"
+
+/*
+PairingHeap is a mergeable priority queue backed by a pairing heap. It exposes the same Enqueue() and Dequeue()
+methods as PriorityQueue (highest priority first, FIFO order as tiebreaker), and additionally allows:
+
+- Melding: absorbing all the items of another PairingHeap in O(1).
+
+- Updating: changing the priority of an enqueued item through its Handle. Raising a priority takes O(1),
+while lowering it takes amortized O(log n).
+
+Dequeue() takes amortized O(log n). When melding heaps, items with equal priority coming from different heaps
+are ordered by their insertion counters, which only guarantee FIFO order among items of the same heap.
+
+Note that the implementation is NOT thread-safe.
+*/
+type PairingHeap struct {
+	root    *Handle
+	size    int
+	counter uint64 // counter ensures FIFO when priority between elements is equal
+}
+
+// NewPairingHeap initializes an empty PairingHeap and returns the instance.
+func NewPairingHeap() *PairingHeap {
+	return new(PairingHeap)
+}
+
+// Enqueue adds an interface item and its priority into the PairingHeap. Returns the Handle of the item.
+func (ph *PairingHeap) Enqueue(item interface{}, priority float64) *Handle {
+	if ph.counter >= maxCounter {
+		ph.counter = renormalizeCounters(handleCounters(ph.root))
+	}
+	node := &Handle{value: item, priority: priority, counter: ph.counter, queued: true}
+	ph.counter++
+	ph.root = linkPairs(ph.root, node)
+	ph.size++
+	return node
+}
+
+// Dequeue removes the item in the PairingHeap with the highest priority, or insertion order when there's no
+// higher priority contents. If the heap is empty, returns nil.
+func (ph *PairingHeap) Dequeue() interface{} {
+	if ph.size == 0 {
+		return nil
+	}
+	node := ph.root
+	ph.root = mergePairs(node.left)
+	node.left = nil
+	node.queued = false
+	ph.size--
+	if ph.size == 0 {
+		ph.counter = 0 // reset FIFO ordering counter once drained
+	}
+	return node.value
+}
+
+// Peek returns the item Dequeue() would remove (nil if empty) without removing it.
+func (ph *PairingHeap) Peek() interface{} {
+	if ph.size == 0 {
+		return nil
+	}
+	return ph.root.value
+}
+
+// Meld moves all the items of other into the PairingHeap, leaving other empty. Handles of other remain valid.
+func (ph *PairingHeap) Meld(other *PairingHeap) {
+	if other == ph || other.size == 0 {
+		return
+	}
+	ph.root = linkPairs(ph.root, other.root)
+	ph.size += other.size
+	if other.counter > ph.counter {
+		ph.counter = other.counter
+	}
+	other.root, other.size, other.counter = nil, 0, 0
+}
+
+// UpdatePriority sets the priority of the item referenced by handle. Returns an error if it was already dequeued.
+func (ph *PairingHeap) UpdatePriority(handle *Handle, priority float64) error {
+	if !handle.queued {
+		return errors.New("cannot UpdatePriority() handle no longer queued")
+	}
+	raise := priority > handle.priority
+	if handle != ph.root {
+		ph.cut(handle)
+	} else if !raise {
+		ph.root = nil
+	}
+	if !raise {
+		// The subtree of the handle may now precede it; merge its children back into the heap on their own.
+		ph.root = linkPairs(ph.root, mergePairs(handle.left))
+		handle.left = nil
+	}
+	handle.priority = priority
+	if handle != ph.root {
+		ph.root = linkPairs(ph.root, handle)
+	}
+	return nil
+}
+
+// Size returns the size of the PairingHeap.
+func (ph *PairingHeap) Size() int {
+	return ph.size
+}
+
+// Internal function which detaches the subtree rooted at node from the heap. node must not be the root.
+func (ph *PairingHeap) cut(node *Handle) {
+	if node.parent.left == node {
+		node.parent.left = node.right
+	} else {
+		node.parent.right = node.right
+	}
+	if node.right != nil {
+		node.right.parent = node.parent
+	}
+	node.parent, node.right = nil, nil
+}
+
+// Internal function which links two heap trees, making the root dequeued last the first child of the other one.
+func linkPairs(a, b *Handle) *Handle {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	if b.before(a) {
+		a, b = b, a
+	}
+	b.right = a.left
+	if a.left != nil {
+		a.left.parent = b
+	}
+	b.parent = a
+	a.left = b
+	a.right, a.parent = nil, nil
+	return a
+}
+
+// Internal function which merges a list of sibling trees into a single one, following the two-pass strategy:
+// siblings are linked in pairs from left to right, and the results are then linked from right to left.
+func mergePairs(first *Handle) *Handle {
+	var pairs []*Handle
+	for first != nil {
+		a, b := first, first.right
+		if b == nil {
+			first = nil
+		} else {
+			first = b.right
+		}
+		a.right, a.parent = nil, nil
+		if b != nil {
+			b.right, b.parent = nil, nil
+		}
+		pairs = append(pairs, linkPairs(a, b))
+	}
+	var root *Handle
+	for i := len(pairs) - 1; i >= 0; i-- {
+		root = linkPairs(pairs[i], root)
+	}
+	return root
+}
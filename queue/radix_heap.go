@@ -0,0 +1,123 @@
+package gost
+
+import (
+	"errors"
+	"math/bits"
+	"sort"
+)
+
+/*
+RadixHeap is a monotone min. priority queue for non-negative integer priorities, as found in shortest-path
+searches and discrete event simulations. Priorities may never be lower than the one of the last dequeued item,
+which allows bucketing items by the highest bit in which they differ from it instead of keeping a full heap
+order. It allows:
+
+- Enqueuing: inserting an item along with its priority in O(1). Priorities lower than Last() are rejected.
+
+- De-queuing: retrieving the item with the lowest priority in amortized O(log C), C being the largest priority.
+
+This implementation uses FIFO order as tiebreaker when elements have the same priority.
+
+Note that the implementation is NOT thread-safe.
+*/
+type RadixHeap struct {
+	buckets [65]radixBucket // buckets[i] holds priorities whose highest bit differing from last is bit i-1
+	last    uint64
+	size    int
+	counter uint64 // counter ensures FIFO when priority between elements is equal
+}
+
+// radixItem wraps a value with its priority and insertion counter.
+type radixItem struct {
+	value    interface{}
+	priority uint64
+	counter  uint64
+}
+
+// radixBucket is a list of radixItems, sortable by insertion counter.
+type radixBucket []radixItem
+
+func (rb radixBucket) Len() int           { return len(rb) }
+func (rb radixBucket) Less(i, j int) bool { return rb[i].counter < rb[j].counter }
+func (rb radixBucket) Swap(i, j int)      { rb[i], rb[j] = rb[j], rb[i] }
+
+// NewRadixHeap initializes an empty RadixHeap and returns the instance.
+func NewRadixHeap() *RadixHeap {
+	return new(RadixHeap)
+}
+
+// Enqueue adds an interface item and its priority into the RadixHeap.
+// Returns an error if priority is lower than the one of the last dequeued item.
+func (rh *RadixHeap) Enqueue(item interface{}, priority uint64) error {
+	if priority < rh.last {
+		return errors.New("cannot Enqueue() priority lower than last dequeued one")
+	}
+	if rh.counter >= maxCounter {
+		var counters []*uint64
+		for i := range rh.buckets {
+			for j := range rh.buckets[i] {
+				counters = append(counters, &rh.buckets[i][j].counter)
+			}
+		}
+		rh.counter = renormalizeCounters(counters)
+	}
+	i := bits.Len64(priority ^ rh.last)
+	rh.buckets[i] = append(rh.buckets[i], radixItem{value: item, priority: priority, counter: rh.counter})
+	rh.counter++
+	rh.size++
+	return nil
+}
+
+// Dequeue removes the item in the RadixHeap with the lowest priority, or insertion order when there's no lower
+// priority contents. If the heap is empty, returns nil.
+func (rh *RadixHeap) Dequeue() interface{} {
+	if rh.size == 0 {
+		return nil
+	}
+	if len(rh.buckets[0]) == 0 {
+		rh.redistribute()
+	}
+	item := rh.buckets[0][0]
+	rh.buckets[0][0] = radixItem{}
+	rh.buckets[0] = rh.buckets[0][1:]
+	rh.size--
+	if rh.size == 0 {
+		rh.counter = 0 // reset FIFO ordering counter once drained
+	}
+	return item.value
+}
+
+// Last returns the priority of the last dequeued item, which is the lowest priority Enqueue() accepts.
+func (rh *RadixHeap) Last() uint64 {
+	return rh.last
+}
+
+// Size returns the size of the RadixHeap.
+func (rh *RadixHeap) Size() int {
+	return rh.size
+}
+
+// Internal function which takes the lowest non-empty bucket, moves last up to its minimum priority and spreads its
+// items over the lower buckets. Items equal to the new last end up in bucket zero, sorted back into FIFO order.
+func (rh *RadixHeap) redistribute() {
+	i := 1
+	for len(rh.buckets[i]) == 0 {
+		i++
+	}
+	bucket := rh.buckets[i]
+	rh.buckets[i] = nil
+	rh.last = bucket[0].priority
+	for _, item := range bucket[1:] {
+		if item.priority < rh.last {
+			rh.last = item.priority
+		}
+	}
+	for _, item := range bucket {
+		j := bits.Len64(item.priority ^ rh.last)
+		rh.buckets[j] = append(rh.buckets[j], item)
+	}
+	// Items may have reached this bucket from higher ones after later items were enqueued directly into it.
+	if len(rh.buckets[0]) > 1 {
+		sort.Sort(rh.buckets[0])
+	}
+}
@@ -0,0 +1,345 @@
+package gost
+
+import (
+	"context"
+	"math"
+	"sort"
+	"sync"
+	"time"
+)
+
+/*
+RateLimitedQueue wraps any Queue so that items are only released within the budget of a token bucket: tokens are
+refilled at a given rate (per second) up to a burst size, and each released item consumes one. It allows:
+
+- Enqueuing: adding an item to the wrapped queue, without any limit.
+
+- Taking: blocking until an item is available and the budget allows releasing it, or the context is done.
+
+- De-queuing: releasing the next item only if the budget allows it right now (nil otherwise).
+
+- Limiting per key: additionally giving each key (as computed by a key function) a token bucket of its own. Items
+whose key is out of budget are held aside, so that items of other keys keep flowing past them; items of the same
+key are still released in FIFO order, and otherwise the oldest item within budget goes first. Releasing an item
+takes O(k), k being the amount of keys with items held aside.
+
+- Adjusting: changing rates and burst sizes at any time; tokens accrued so far are kept (up to the new burst).
+
+Time is read from an injectable Clock. The queue is safe for concurrent use: blocked Take() calls are woken up
+whenever items are enqueued or limits change. The wrapped queue must not be accessed directly while wrapped.
+*/
+type RateLimitedQueue struct {
+	mutex    sync.Mutex
+	changed  chan struct{} // closed and replaced whenever items are enqueued or limits change
+	queue    Queue
+	lanes    map[interface{}]*rateLane // items pulled out of queue, by key (a single nil key without per-key limits)
+	held     int                       // amount of items in lanes
+	sequence uint64                    // arrival order of the next item pulled out of queue
+	clock    Clock
+	bucket   tokenBucket
+	keyOf    func(item interface{}) interface{}
+	keyRate  float64
+	keyBurst int
+	keys     map[interface{}]*tokenBucket
+}
+
+// rateLane holds the items of a key pulled out of the wrapped queue, oldest first.
+type rateLane struct {
+	key     interface{}
+	entries []rateEntry
+}
+
+// rateEntry wraps an item with its arrival order.
+type rateEntry struct {
+	sequence uint64
+	item     interface{}
+}
+
+// noWait is the wait for budget which will never be available without a change of limits.
+const noWait = time.Duration(math.MaxInt64)
+
+// NewRateLimitedQueue wraps queue, releasing at most rate items per second with bursts of up to burst items.
+// The bucket starts full. If clock is nil, the system clock is used.
+func NewRateLimitedQueue(queue Queue, rate float64, burst int, clock Clock) *RateLimitedQueue {
+	if clock == nil {
+		clock = systemClock{}
+	}
+	rlq := &RateLimitedQueue{
+		changed: make(chan struct{}),
+		queue:   queue,
+		lanes:   make(map[interface{}]*rateLane),
+		clock:   clock,
+	}
+	rlq.bucket = newTokenBucket(rate, burst, clock.Now())
+	return rlq
+}
+
+// Enqueue adds data (interface{}) to the tail of the wrapped queue.
+func (rlq *RateLimitedQueue) Enqueue(data interface{}) {
+	rlq.mutex.Lock()
+	defer rlq.mutex.Unlock()
+	rlq.queue.Enqueue(data)
+	rlq.notify()
+}
+
+// Dequeue releases the next item if the budget allows it right now. Returns nil otherwise, or if empty.
+func (rlq *RateLimitedQueue) Dequeue() interface{} {
+	rlq.mutex.Lock()
+	defer rlq.mutex.Unlock()
+	lane, _ := rlq.next(rlq.clock.Now())
+	if lane == nil {
+		return nil
+	}
+	return rlq.release(lane)
+}
+
+// Take releases the next item, waiting for one to be enqueued and for the budget to allow it. Returns the context
+// error if ctx is done before then.
+func (rlq *RateLimitedQueue) Take(ctx context.Context) (interface{}, error) {
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		rlq.mutex.Lock()
+		lane, wait := rlq.next(rlq.clock.Now())
+		if lane != nil {
+			item := rlq.release(lane)
+			rlq.mutex.Unlock()
+			return item, nil
+		}
+		changed := rlq.changed
+		rlq.mutex.Unlock()
+		var timer <-chan time.Time // nil, blocking forever, if only a change can provide budget
+		if wait != noWait {
+			timer = rlq.clock.After(wait)
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-changed:
+		case <-timer:
+		}
+	}
+}
+
+// SetRate changes the rate (items per second) and burst size of the queue-wide budget.
+func (rlq *RateLimitedQueue) SetRate(rate float64, burst int) {
+	rlq.mutex.Lock()
+	defer rlq.mutex.Unlock()
+	rlq.bucket.set(rate, burst, rlq.clock.Now())
+	rlq.notify()
+}
+
+// SetKeyLimit gives every key, as computed by keyOf from each item, its own budget of rate items per second with
+// bursts of up to burst items, on top of the queue-wide one. Existing key budgets are adjusted accordingly.
+// A nil keyOf removes per-key limits.
+func (rlq *RateLimitedQueue) SetKeyLimit(keyOf func(item interface{}) interface{}, rate float64, burst int) {
+	rlq.mutex.Lock()
+	defer rlq.mutex.Unlock()
+	rlq.keyOf, rlq.keyRate, rlq.keyBurst = keyOf, rate, burst
+	if keyOf == nil {
+		rlq.keys = nil
+	} else {
+		if rlq.keys == nil {
+			rlq.keys = make(map[interface{}]*tokenBucket)
+		}
+		now := rlq.clock.Now()
+		for _, bucket := range rlq.keys {
+			bucket.set(rate, burst, now)
+		}
+	}
+	rlq.regroup()
+	rlq.notify()
+}
+
+// Size returns the amount of items waiting to be released.
+func (rlq *RateLimitedQueue) Size() int {
+	rlq.mutex.Lock()
+	defer rlq.mutex.Unlock()
+	return rlq.size()
+}
+
+// Internal function returning the amount of items waiting to be released.
+func (rlq *RateLimitedQueue) size() int {
+	return rlq.queue.Size() + rlq.held
+}
+
+// Internal function returning the lane whose head has to be released next, if the budget allows it at now.
+// Otherwise, returns how long it takes until some item could be released (noWait if only a change can help).
+func (rlq *RateLimitedQueue) next(now time.Time) (*rateLane, time.Duration) {
+	if rlq.size() == 0 {
+		return nil, noWait
+	}
+	if wait := rlq.bucket.wait(now); wait > 0 {
+		return nil, wait
+	}
+	// Held items are older than the ones still in the wrapped queue, so the oldest one within budget goes first.
+	var best *rateLane
+	wait := noWait
+	for _, lane := range rlq.lanes {
+		if keyWait := rlq.keyWait(lane.key, now); keyWait > 0 {
+			if keyWait < wait {
+				wait = keyWait
+			}
+		} else if best == nil || lane.entries[0].sequence < best.entries[0].sequence {
+			best = lane
+		}
+	}
+	// Otherwise, items are pulled until one is within budget; every other lane was out of budget already.
+	for best == nil && rlq.queue.Size() > 0 {
+		lane := rlq.pull()
+		if keyWait := rlq.keyWait(lane.key, now); keyWait > 0 {
+			if keyWait < wait {
+				wait = keyWait
+			}
+		} else {
+			best = lane
+		}
+	}
+	return best, wait
+}
+
+// Internal function which moves the head of the wrapped queue to the lane of its key, returning the lane.
+func (rlq *RateLimitedQueue) pull() *rateLane {
+	entry := rateEntry{sequence: rlq.sequence, item: rlq.queue.Dequeue()}
+	rlq.sequence++
+	rlq.held++
+	return rlq.hold(entry)
+}
+
+// Internal function which appends entry to the lane of its key, creating the lane if needed.
+func (rlq *RateLimitedQueue) hold(entry rateEntry) *rateLane {
+	var key interface{}
+	if rlq.keyOf != nil {
+		key = rlq.keyOf(entry.item)
+	}
+	lane, ok := rlq.lanes[key]
+	if !ok {
+		lane = &rateLane{key: key}
+		rlq.lanes[key] = lane
+	}
+	lane.entries = append(lane.entries, entry)
+	return lane
+}
+
+// Internal function which redistributes held items among lanes after the key function changed.
+func (rlq *RateLimitedQueue) regroup() {
+	entries := make([]rateEntry, 0, rlq.held)
+	for _, lane := range rlq.lanes {
+		entries = append(entries, lane.entries...)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].sequence < entries[j].sequence })
+	rlq.lanes = make(map[interface{}]*rateLane)
+	for _, entry := range entries {
+		rlq.hold(entry)
+	}
+}
+
+// Internal function which consumes the budget for the head of lane and releases it.
+func (rlq *RateLimitedQueue) release(lane *rateLane) interface{} {
+	rlq.bucket.tokens--
+	if bucket := rlq.keyBucket(lane.key); bucket != nil {
+		bucket.tokens--
+	}
+	item := lane.entries[0].item
+	lane.entries[0] = rateEntry{}
+	lane.entries = lane.entries[1:]
+	if len(lane.entries) == 0 {
+		delete(rlq.lanes, lane.key)
+	}
+	rlq.held--
+	if len(rlq.keys) > 2*(rlq.size()+1) {
+		rlq.prune()
+	}
+	return item
+}
+
+// Internal function returning how long key has to wait for budget of its own (zero without per-key limits).
+func (rlq *RateLimitedQueue) keyWait(key interface{}, now time.Time) time.Duration {
+	if bucket := rlq.keyBucket(key); bucket != nil {
+		return bucket.wait(now)
+	}
+	return 0
+}
+
+// Internal function returning the bucket of key, or nil if per-key limits are disabled.
+func (rlq *RateLimitedQueue) keyBucket(key interface{}) *tokenBucket {
+	if rlq.keyOf == nil {
+		return nil
+	}
+	bucket, ok := rlq.keys[key]
+	if !ok {
+		b := newTokenBucket(rlq.keyRate, rlq.keyBurst, rlq.clock.Now())
+		bucket = &b
+		rlq.keys[key] = bucket
+	}
+	return bucket
+}
+
+// Internal function dropping key buckets which are full again and hold no items, as they are equivalent to fresh ones.
+func (rlq *RateLimitedQueue) prune() {
+	now := rlq.clock.Now()
+	for key, bucket := range rlq.keys {
+		if _, held := rlq.lanes[key]; held {
+			continue
+		}
+		if bucket.refill(now); bucket.tokens >= bucket.burst {
+			delete(rlq.keys, key)
+		}
+	}
+}
+
+// Internal function which wakes up every blocked Take() call.
+func (rlq *RateLimitedQueue) notify() {
+	close(rlq.changed)
+	rlq.changed = make(chan struct{})
+}
+
+// tokenBucket holds a budget of tokens, refilled lazily at rate tokens per second up to burst tokens.
+type tokenBucket struct {
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// Internal function which creates a full token bucket.
+func newTokenBucket(rate float64, burst int, now time.Time) tokenBucket {
+	return tokenBucket{rate: rate, burst: float64(burst), tokens: float64(burst), last: now}
+}
+
+// Internal function which adds the tokens accrued since the last refill.
+func (tb *tokenBucket) refill(now time.Time) {
+	if elapsed := now.Sub(tb.last).Seconds(); elapsed > 0 {
+		tb.tokens = math.Min(tb.burst, tb.tokens+elapsed*tb.rate)
+	}
+	tb.last = now
+}
+
+// Internal function which changes the rate and burst of the bucket, keeping the tokens accrued so far.
+func (tb *tokenBucket) set(rate float64, burst int, now time.Time) {
+	tb.refill(now)
+	tb.rate, tb.burst = rate, float64(burst)
+	tb.tokens = math.Min(tb.tokens, tb.burst)
+}
+
+// Internal function returning how long it takes until a token is available (zero if there is one already).
+// Buckets which can never provide a token (zero rate or burst), or only in centuries, return noWait.
+func (tb *tokenBucket) wait(now time.Time) time.Duration {
+	tb.refill(now)
+	if tb.tokens >= 1 {
+		return 0
+	}
+	if tb.rate <= 0 || tb.burst < 1 {
+		return noWait
+	}
+	// Tiny rates would overflow time.Duration, so waits are capped to the longest one.
+	nanoseconds := math.Ceil((1 - tb.tokens) / tb.rate * float64(time.Second))
+	if nanoseconds >= math.MaxInt64 {
+		return noWait
+	}
+	if nanoseconds < 1 {
+		return 1
+	}
+	return time.Duration(nanoseconds)
+}
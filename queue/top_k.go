@@ -0,0 +1,160 @@
+package gost
+
+import (
+	"container/heap"
+	"errors"
+	"sort"
+)
+
+/*
+TopK is a bounded priority container which only retains the K best items offered to it. It keeps its
+contents in a heap ordered opposite to the desired output, so the worst retained item sits at the root and
+can be compared against (and evicted by) new items in O(log K). It allows:
+
+- Offering: submitting an item and its priority; the item is kept only if it ranks among the K best.
+
+- Sorting: obtaining the retained items, best first.
+
+- Merging: streaming the contents of other TopK instances into this one.
+
+Items with equal priority are ranked in FIFO order, consistently with PriorityQueue and MinPriorityQueue:
+an item offered later never evicts an earlier one with the same priority.
+
+Note that the implementation is NOT thread-safe.
+*/
+type TopK struct {
+	contents topKContents
+	k        int
+	counter  uint64 // counter ensures FIFO when priority between elements is equal
+}
+
+// NewTopK creates a TopK which retains the k items with the highest priority.
+func NewTopK(k int) *TopK {
+	return &TopK{k: k, contents: topKContents{highest: true}}
+}
+
+// NewMinTopK creates a TopK which retains the k items with the lowest priority.
+func NewMinTopK(k int) *TopK {
+	return &TopK{k: k, contents: topKContents{highest: false}}
+}
+
+// Offer submits an item with its priority. Returns whether the item was accepted, along with the item evicted
+// to make room for it (nil if none was). A rejected item is returned as evicted, as it was never retained.
+func (tk *TopK) Offer(item interface{}, priority float64) (accepted bool, evicted interface{}) {
+	if tk.k <= 0 {
+		return false, item
+	}
+	if tk.counter >= maxCounter {
+		tk.counter = renormalizeCounters(priorityItemCounters(tk.contents.items))
+	}
+	candidate := newPriorityItem(item, priority, tk.counter)
+	tk.counter++
+	if tk.contents.Len() < tk.k {
+		heap.Push(&tk.contents, candidate)
+		return true, nil
+	}
+	worst := tk.contents.items[0]
+	if !tk.contents.outranks(candidate, worst) {
+		return false, item
+	}
+	tk.contents.items[0] = candidate
+	candidate.index = 0
+	heap.Fix(&tk.contents, 0)
+	return true, worst.value
+}
+
+// Sorted returns the retained items ordered from best to worst. The TopK is left untouched.
+func (tk *TopK) Sorted() []interface{} {
+	items := make([]*priorityItem, len(tk.contents.items))
+	copy(items, tk.contents.items)
+	sort.Slice(items, func(i, j int) bool { return tk.contents.outranks(items[i], items[j]) })
+	values := make([]interface{}, len(items))
+	for i, item := range items {
+		values[i] = item.value
+	}
+	return values
+}
+
+// Merge offers the retained items of every TopK in others to this one, best first. The others are left untouched,
+// and the TopK itself is skipped if found among them. Merged items are considered to be offered after the ones
+// already retained, so they lose ties against them. Returns an error, merging nothing, if any of the others ranks
+// in the opposite direction (highest or lowest).
+func (tk *TopK) Merge(others ...*TopK) error {
+	for _, other := range others {
+		if other.contents.highest != tk.contents.highest {
+			return errors.New("cannot Merge() TopK ranking in the opposite direction")
+		}
+	}
+	for _, other := range others {
+		if other == tk {
+			continue
+		}
+		items := make([]*priorityItem, len(other.contents.items))
+		copy(items, other.contents.items)
+		sort.Slice(items, func(i, j int) bool { return other.contents.outranks(items[i], items[j]) })
+		for _, item := range items {
+			if accepted, _ := tk.Offer(item.value, item.priority); !accepted {
+				// Remaining items in other rank even lower, so they would be rejected as well.
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// Size returns the amount of items currently retained by the TopK (at most K).
+func (tk *TopK) Size() int {
+	return tk.contents.Len()
+}
+
+/*
+	The type defined below implements heap.Interface.
+	TopK is intended to abstract the underlying implementation details.
+*/
+
+// topKContents implements heap.Interface and holds priorityItems, keeping the worst ranked item at the root.
+type topKContents struct {
+	items   []*priorityItem
+	highest bool // whether higher priorities rank better
+}
+
+// outranks responds whether item a ranks better than item b, using FIFO order as tiebreaker.
+func (tc topKContents) outranks(a, b *priorityItem) bool {
+	if a.priority == b.priority {
+		return a.counter < b.counter
+	}
+	if tc.highest {
+		return a.priority > b.priority
+	}
+	return a.priority < b.priority
+}
+
+// Len returns the length of topKContents.
+func (tc topKContents) Len() int { return len(tc.items) }
+
+// Less responds whether item in index i ranks worse than j, so that it is closer to the root.
+func (tc topKContents) Less(i, j int) bool {
+	return tc.outranks(tc.items[j], tc.items[i])
+}
+
+// Swap switches places between both priorityItems in the designated indices.
+func (tc topKContents) Swap(i, j int) {
+	tc.items[i], tc.items[j] = tc.items[j], tc.items[i]
+	tc.items[i].index = i
+	tc.items[j].index = j
+}
+
+// Push expects an element x of type *priorityItem and appends it to topKContents.
+func (tc *topKContents) Push(x interface{}) {
+	item := x.(*priorityItem)
+	item.index = len(tc.items)
+	tc.items = append(tc.items, item)
+}
+
+// Pop removes the worst ranked item from topKContents.
+func (tc *topKContents) Pop() interface{} {
+	old := tc.items
+	item := old[len(old)-1]
+	tc.items = old[0 : len(old)-1]
+	return item
+}
@@ -0,0 +1,59 @@
+package gost
+
+import (
+	"github.com/christat/gost/list"
+)
+
+/*
+ImmutableStack is a persistent stack backed by a singly linked list. Pushing and popping do not modify a stack;
+they return a new version of it instead, sharing every Node below its top with the original one. It allows:
+
+- Pushing: obtaining a new stack with an element on top of the current one, in O(1).
+
+- Popping: obtaining the element on top of the stack along with the stack below it, in O(1).
+
+- Peeking: obtaining the element on top of the stack.
+
+Since versions are never modified, they can be kept around as snapshots at no cost (e.g. for undo histories or
+backtracking). A nil *ImmutableStack is a valid empty stack.
+*/
+type ImmutableStack struct {
+	head *gost.Node
+	size int
+}
+
+// Push returns a new stack with data (interface{}) on top of the current one.
+func (stack *ImmutableStack) Push(data interface{}) *ImmutableStack {
+	var head *gost.Node
+	if stack != nil {
+		head = stack.head
+	}
+	return &ImmutableStack{head: &gost.Node{Data: data, Next: head}, size: stack.Size() + 1}
+}
+
+// Pop returns the data on top of the stack and the stack below it. Returns nil and the stack itself if empty.
+func (stack *ImmutableStack) Pop() (interface{}, *ImmutableStack) {
+	if stack.Size() == 0 {
+		return nil, stack
+	}
+	if stack.size == 1 {
+		return stack.head.Data, nil
+	}
+	return stack.head.Data, &ImmutableStack{head: stack.head.Next, size: stack.size - 1}
+}
+
+// Peek at the content of the stack head (nil if empty).
+func (stack *ImmutableStack) Peek() interface{} {
+	if stack.Size() == 0 {
+		return nil
+	}
+	return stack.head.Data
+}
+
+// Size returns the depth of the current ImmutableStack.
+func (stack *ImmutableStack) Size() int {
+	if stack == nil {
+		return 0
+	}
+	return stack.size
+}
@@ -0,0 +1,59 @@
+package gost
+
+import (
+	"github.com/christat/gost/list"
+)
+
+// Iterator returns a gost.Iterator over the stack contents, from top to bottom, without removing them.
+func (stack *NodeStack) Iterator() gost.Iterator {
+	return gost.NewNodeIterator(stack.head)
+}
+
+// Iterator returns a gost.Iterator over the stack contents, from top to bottom, without removing them.
+func (stack *SliceStack) Iterator() gost.Iterator {
+	return &sliceStackIterator{slice: stack.slice, index: len(stack.slice)}
+}
+
+// Drain returns a gost.Iterator which pops every element of stack as it is reached, leaving it empty at the end.
+func Drain(stack Stack) gost.Iterator {
+	return &drainIterator{stack: stack}
+}
+
+// sliceStackIterator walks a slice from last to first.
+type sliceStackIterator struct {
+	slice []interface{}
+	index int
+}
+
+func (it *sliceStackIterator) Next() bool {
+	if it.index >= 0 {
+		it.index--
+	}
+	return it.index >= 0
+}
+
+func (it *sliceStackIterator) Value() interface{} {
+	if it.index < 0 || it.index >= len(it.slice) {
+		return nil
+	}
+	return it.slice[it.index]
+}
+
+// drainIterator pops the elements of a Stack.
+type drainIterator struct {
+	stack Stack
+	value interface{}
+}
+
+func (it *drainIterator) Next() bool {
+	if it.stack.Size() == 0 {
+		it.value = nil
+		return false
+	}
+	it.value = it.stack.Pop()
+	return true
+}
+
+func (it *drainIterator) Value() interface{} {
+	return it.value
+}
@@ -0,0 +1,208 @@
+package gost_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/christat/gost/queue"
+)
+
+// concatenation is associative but not commutative, so it also checks that items are combined in order.
+func concat(a, b interface{}) interface{} {
+	return a.(string) + b.(string)
+}
+
+func maxInt(a, b interface{}) interface{} {
+	if a.(int) > b.(int) {
+		return a
+	}
+	return b
+}
+
+// brute-force model of the expected aggregate.
+func concatAll(items []string) interface{} {
+	if len(items) == 0 {
+		return nil
+	}
+	result := ""
+	for _, item := range items {
+		result += item
+	}
+	return result
+}
+
+func TestAggregatingQueue_Dequeue(t *testing.T) {
+	queue := gost.NewAggregatingQueue(maxInt)
+	if queue.Dequeue() != nil {
+		t.Error("Dequeue() did not return nil on empty queue")
+	}
+	for i := 0; i < num; i++ {
+		queue.Enqueue(i)
+	}
+	if queue.Size() != num {
+		t.Errorf("Enqueue() error; queue size expected: %v, got: %v", num, queue.Size())
+	}
+	for i := 0; i < num; i++ {
+		if value := queue.Dequeue(); value != i {
+			t.Fatalf("Dequeue() error; expected: %v, got: %v", i, value)
+		}
+	}
+	if queue.Size() != 0 {
+		t.Errorf("Dequeue() error; queue size expected: %v, got: %v", 0, queue.Size())
+	}
+}
+
+func TestAggregatingQueue_Aggregate(t *testing.T) {
+	queue := gost.NewAggregatingQueue(concat)
+	if queue.Aggregate() != nil {
+		t.Error("Aggregate() did not return nil on empty queue")
+	}
+	var model []string
+	random := rand.New(rand.NewSource(42))
+	for i := 0; i < num; i++ {
+		if random.Intn(2) == 0 || len(model) == 0 {
+			item := string(rune('a' + random.Intn(26)))
+			queue.Enqueue(item)
+			model = append(model, item)
+		} else {
+			if value := queue.Dequeue(); value != model[0] {
+				t.Fatalf("Dequeue() error; expected: %v, got: %v", model[0], value)
+			}
+			model = model[1:]
+		}
+		if aggregate := queue.Aggregate(); aggregate != concatAll(model) {
+			t.Fatalf("Aggregate() error; expected: %v, got: %v", concatAll(model), aggregate)
+		}
+	}
+}
+
+func TestAggregatingQueue_SlidingWindow(t *testing.T) {
+	const window = 5
+	queue := gost.NewAggregatingQueue(maxInt)
+	values := []int{3, 1, 4, 1, 5, 9, 2, 6, 5, 3, 5, 8, 9, 7, 9}
+	for i, value := range values {
+		queue.Enqueue(value)
+		if queue.Size() > window {
+			queue.Dequeue()
+		}
+		expected := values[i]
+		for j := i; j >= 0 && j > i-window; j-- {
+			if values[j] > expected {
+				expected = values[j]
+			}
+		}
+		if aggregate := queue.Aggregate(); aggregate != expected {
+			t.Errorf("Aggregate() error at step %v; expected: %v, got: %v", i, expected, aggregate)
+		}
+	}
+}
+
+func TestAggregatingDeque_Aggregate(t *testing.T) {
+	deque := gost.NewAggregatingDeque(concat)
+	if deque.PopFront() != nil || deque.PopBack() != nil {
+		t.Error("Pop() did not return nil on empty deque")
+	}
+	var model []string
+	random := rand.New(rand.NewSource(42))
+	for i := 0; i < num; i++ {
+		item := string(rune('a' + random.Intn(26)))
+		switch random.Intn(4) {
+		case 0:
+			deque.PushFront(item)
+			model = append([]string{item}, model...)
+		case 1:
+			deque.PushBack(item)
+			model = append(model, item)
+		case 2:
+			value := deque.PopFront()
+			if len(model) == 0 {
+				if value != nil {
+					t.Fatalf("PopFront() error; expected: nil, got: %v", value)
+				}
+				break
+			}
+			if value != model[0] {
+				t.Fatalf("PopFront() error; expected: %v, got: %v", model[0], value)
+			}
+			model = model[1:]
+		case 3:
+			value := deque.PopBack()
+			if len(model) == 0 {
+				if value != nil {
+					t.Fatalf("PopBack() error; expected: nil, got: %v", value)
+				}
+				break
+			}
+			if value != model[len(model)-1] {
+				t.Fatalf("PopBack() error; expected: %v, got: %v", model[len(model)-1], value)
+			}
+			model = model[:len(model)-1]
+		}
+		if deque.Size() != len(model) {
+			t.Fatalf("Size() error; expected: %v, got: %v", len(model), deque.Size())
+		}
+		if aggregate := deque.Aggregate(); aggregate != concatAll(model) {
+			t.Fatalf("Aggregate() error; expected: %v, got: %v", concatAll(model), aggregate)
+		}
+	}
+}
+
+func TestDABAQueue_Aggregate(t *testing.T) {
+	queue := gost.NewDABAQueue(concat)
+	if queue.Dequeue() != nil || queue.Aggregate() != nil {
+		t.Error("Dequeue() did not return nil on empty queue")
+	}
+	var model []string
+	random := rand.New(rand.NewSource(42))
+	for i := 0; i < num; i++ {
+		// Bias the operations in phases, so that the queue grows and shrinks across many chunks.
+		enqueueOdds := 3
+		if i/1000%2 == 1 {
+			enqueueOdds = 1
+		}
+		if random.Intn(4) < enqueueOdds || len(model) == 0 {
+			item := string(rune('a' + random.Intn(26)))
+			queue.Enqueue(item)
+			model = append(model, item)
+		} else {
+			if value := queue.Dequeue(); value != model[0] {
+				t.Fatalf("Dequeue() error; expected: %v, got: %v", model[0], value)
+			}
+			model = model[1:]
+		}
+		if queue.Size() != len(model) {
+			t.Fatalf("Size() error; expected: %v, got: %v", len(model), queue.Size())
+		}
+		if aggregate := queue.Aggregate(); aggregate != concatAll(model) {
+			t.Fatalf("Aggregate() error; expected: %v, got: %v", concatAll(model), aggregate)
+		}
+	}
+}
+
+func TestDABAQueue_WorstCase(t *testing.T) {
+	calls := 0
+	queue := gost.NewDABAQueue(func(a, b interface{}) interface{} {
+		calls++
+		return maxInt(a, b)
+	})
+	// No operation may combine more than a constant amount of times, no matter how long the queue is.
+	const maxCalls = 4
+	for i := 0; i < 2*num; i++ {
+		calls = 0
+		if i < num || i%3 == 0 {
+			queue.Enqueue(i)
+		} else {
+			queue.Dequeue()
+		}
+		if calls > maxCalls {
+			t.Fatalf("operation %v error; expected at most %v combine calls, got: %v", i, maxCalls, calls)
+		}
+	}
+	for queue.Size() > 0 {
+		calls = 0
+		queue.Dequeue()
+		if calls > maxCalls {
+			t.Fatalf("Dequeue() error; expected at most %v combine calls, got: %v", maxCalls, calls)
+		}
+	}
+}
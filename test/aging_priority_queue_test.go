@@ -0,0 +1,95 @@
+package gost_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/christat/gost/queue"
+)
+
+func TestAgingPriorityQueue_Dequeue(t *testing.T) {
+	clock := newFakeClock()
+	pq := gost.NewAgingPriorityQueue(gost.LinearAging(1), 0, clock)
+	if pq.Dequeue() != nil {
+		t.Error("Dequeue() failed: AgingPriorityQueue returned non-nil value when empty")
+	}
+	pq.Enqueue("a", 0)
+	pq.Enqueue("b", 5)
+	pq.Enqueue("c", 10)
+	pq.Enqueue("d", 5)
+	for _, expected := range []string{"c", "b", "d", "a"} {
+		if value := pq.Dequeue(); value != expected {
+			t.Errorf("Dequeue() failed: returned: %v, expected: %v", value, expected)
+		}
+	}
+}
+
+func TestAgingPriorityQueue_Starvation(t *testing.T) {
+	clock := newFakeClock()
+	pq := gost.NewAgingPriorityQueue(gost.LinearAging(1), 0, clock)
+	pq.Enqueue("low", 0)
+	// Sustained high priority load: a new item of priority 5 arrives every second, and one item is served.
+	for i := 0; i < 10; i++ {
+		clock.Advance(time.Second)
+		pq.Enqueue("high", 5)
+		if value := pq.Dequeue(); value == "low" {
+			if i+1 < 5 {
+				t.Errorf("Dequeue() failed: low priority item served after %v seconds, expected at least 5", i+1)
+			}
+			return
+		}
+	}
+	t.Error("Dequeue() failed: low priority item starved")
+}
+
+func TestAgingPriorityQueue_Exponential(t *testing.T) {
+	clock := newFakeClock()
+	pq := gost.NewAgingPriorityQueue(gost.ExponentialAging(1), 0, clock)
+	pq.Enqueue("old", 0)
+	clock.Advance(3 * time.Second) // e^3 - 1 > 19
+	pq.Enqueue("new", 19)
+	if value := pq.Dequeue(); value != "old" {
+		t.Errorf("Dequeue() failed: returned: %v, expected: %v", value, "old")
+	}
+}
+
+func TestAgingPriorityQueue_Refresh(t *testing.T) {
+	clock := newFakeClock()
+	pq := gost.NewAgingPriorityQueue(gost.LinearAging(1), time.Minute, clock)
+	pq.Enqueue("old", 0)
+	clock.Advance(10 * time.Second)
+	pq.Enqueue("new", 5)
+	// Priorities have not been refreshed within the interval, so "old" is still considered to have priority 0.
+	if value := pq.Dequeue(); value != "new" {
+		t.Errorf("Dequeue() failed before refresh: returned: %v, expected: %v", value, "new")
+	}
+	pq.Enqueue("new", 5)
+	pq.Refresh()
+	if value := pq.Dequeue(); value != "old" {
+		t.Errorf("Dequeue() failed after refresh: returned: %v, expected: %v", value, "old")
+	}
+}
+
+func TestAgingPriorityQueue_Wait(t *testing.T) {
+	clock := newFakeClock()
+	pq := gost.NewAgingPriorityQueue(gost.LinearAging(0), 0, clock)
+	if pq.OldestWait() != 0 {
+		t.Error("OldestWait() failed on empty queue")
+	}
+	pq.Enqueue("a", 0)
+	clock.Advance(3 * time.Second)
+	pq.Enqueue("b", 10)
+	clock.Advance(2 * time.Second)
+	if wait := pq.OldestWait(); wait != 5*time.Second {
+		t.Errorf("OldestWait() failed: returned: %v, expected: %v", wait, 5*time.Second)
+	}
+	pq.Dequeue()
+	if wait := pq.MaxWait(); wait != 2*time.Second {
+		t.Errorf("MaxWait() failed: returned: %v, expected: %v", wait, 2*time.Second)
+	}
+	clock.Advance(time.Second)
+	pq.Dequeue()
+	if wait := pq.MaxWait(); wait != 6*time.Second {
+		t.Errorf("MaxWait() failed: returned: %v, expected: %v", wait, 6*time.Second)
+	}
+}
@@ -0,0 +1,110 @@
+package gost_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/christat/gost/queue"
+)
+
+// test helper function; checks that value is a batch holding the expected items.
+func assertBatch(t *testing.T, value interface{}, expected ...interface{}) {
+	t.Helper()
+	batch, ok := value.([]interface{})
+	if !ok || len(batch) != len(expected) {
+		t.Fatalf("Dequeue() error; expected batch: %v, got: %v", expected, value)
+	}
+	for i := range expected {
+		if batch[i] != expected[i] {
+			t.Fatalf("Dequeue() error; expected batch: %v, got: %v", expected, value)
+		}
+	}
+}
+
+func TestBatchQueue_MaxItems(t *testing.T) {
+	queue := gost.NewBatchQueue(gost.NewQueue(10), 3, 0, nil, 0, newFakeClock())
+	for i := 0; i < 7; i++ {
+		queue.Enqueue(i)
+	}
+	if queue.Size() != 2 || queue.Pending() != 1 {
+		t.Fatalf("Enqueue() error; expected %v batches and %v pending items, got: %v and %v", 2, 1, queue.Size(), queue.Pending())
+	}
+	assertBatch(t, queue.Dequeue(), 0, 1, 2)
+	assertBatch(t, queue.Dequeue(), 3, 4, 5)
+	if value := queue.Dequeue(); value != nil {
+		t.Fatalf("Dequeue() error; expected nil, got: %v", value)
+	}
+	queue.Flush()
+	assertBatch(t, queue.Dequeue(), 6)
+}
+
+func TestBatchQueue_MaxBytes(t *testing.T) {
+	sizer := func(item interface{}) int { return len(item.(string)) }
+	queue := gost.NewBatchQueue(new(gost.NodeQueue), 100, 10, sizer, 0, newFakeClock())
+	for _, item := range []string{"abcd", "efgh", "ij", "klmnopqrstuv", "w"} {
+		queue.Enqueue(item)
+	}
+	assertBatch(t, queue.Dequeue(), "abcd", "efgh", "ij")
+	assertBatch(t, queue.Dequeue(), "klmnopqrstuv")
+	if queue.Pending() != 1 {
+		t.Errorf("Enqueue() error; expected %v pending item, got: %v", 1, queue.Pending())
+	}
+}
+
+func TestBatchQueue_Linger(t *testing.T) {
+	clock := newFakeClock()
+	queue := gost.NewBatchQueue(new(gost.NodeQueue), 100, 0, nil, time.Second, clock)
+	queue.Enqueue("a")
+	clock.Advance(500 * time.Millisecond)
+	queue.Enqueue("b")
+	if value := queue.Dequeue(); value != nil {
+		t.Fatalf("Dequeue() error; batch sealed before lingering: %v", value)
+	}
+	clock.Advance(500 * time.Millisecond)
+	// Lingering counts from the first item of the batch.
+	queue.Enqueue("c")
+	assertBatch(t, queue.Dequeue(), "a", "b")
+	clock.Advance(time.Second)
+	assertBatch(t, queue.Dequeue(), "c")
+}
+
+func TestBatchQueue_Take(t *testing.T) {
+	clock := newFakeClock()
+	queue := gost.NewBatchQueue(new(gost.NodeQueue), 2, 0, nil, time.Second, clock)
+	if _, err := queue.Take(context.Background()); err == nil {
+		t.Error("Take() did not return error on empty queue")
+	}
+	queue.Enqueue("a")
+	queue.Enqueue("b")
+	queue.Enqueue("c")
+	batch, err := queue.Take(context.Background())
+	if err != nil {
+		t.Fatalf("Take() failed unexpectedly: %v", err)
+	}
+	assertBatch(t, batch, "a", "b")
+	result := make(chan []interface{})
+	go func() {
+		batch, _ := queue.Take(context.Background())
+		result <- batch
+	}()
+	clock.WaitForWaiters(1)
+	clock.Advance(time.Second)
+	assertBatch(t, <-result, "c")
+}
+
+func TestBatchQueue_Close(t *testing.T) {
+	queue := gost.NewBatchQueue(new(gost.NodeQueue), 10, 0, nil, 0, newFakeClock())
+	queue.Enqueue("a")
+	queue.Close()
+	if !queue.Closed() {
+		t.Error("Closed() error; expected queue to be closed")
+	}
+	if err := queue.Enqueue("b"); err == nil {
+		t.Error("Enqueue() did not return error on closed queue")
+	}
+	assertBatch(t, queue.Dequeue(), "a")
+	if value := queue.Dequeue(); value != nil {
+		t.Errorf("Dequeue() error; expected nil after draining closed queue, got: %v", value)
+	}
+}
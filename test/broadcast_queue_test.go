@@ -0,0 +1,185 @@
+package gost_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/christat/gost/queue"
+)
+
+// test helper function; reads every available item of subscriber and checks they match the expected ones.
+func assertReads(t *testing.T, subscriber *gost.Subscriber, expected ...interface{}) {
+	t.Helper()
+	for _, item := range expected {
+		if value, ok := subscriber.Next(); !ok || value != item {
+			t.Fatalf("Next() failed: returned: %v, expected: %v", value, item)
+		}
+	}
+	if value, ok := subscriber.Next(); ok {
+		t.Fatalf("Next() failed: returned: %v, expected no more items", value)
+	}
+}
+
+func TestBroadcastQueue_FanOut(t *testing.T) {
+	queue := gost.NewBroadcastQueue(0, 0, gost.DropOldest)
+	fast, slow := queue.Subscribe(false), queue.Subscribe(false)
+	for i := 0; i < num; i++ {
+		queue.Publish(i)
+		if value, ok := fast.Next(); !ok || value != i {
+			t.Fatalf("Next() failed: returned: %v, expected: %v", value, i)
+		}
+	}
+	if queue.Size() != num || slow.Lag() != num || fast.Lag() != 0 {
+		t.Fatalf("Publish() error; expected size and lag: %v, got: %v and %v", num, queue.Size(), slow.Lag())
+	}
+	for i := 0; i < num; i++ {
+		if value, ok := slow.Next(); !ok || value != i {
+			t.Fatalf("Next() failed: returned: %v, expected: %v", value, i)
+		}
+	}
+	if queue.Size() != 0 {
+		t.Errorf("Next() error; expected items to be reclaimed, got size: %v", queue.Size())
+	}
+}
+
+func TestBroadcastQueue_Reclaim(t *testing.T) {
+	queue := gost.NewBroadcastQueue(0, 0, gost.DropOldest)
+	queue.Publish("unobserved")
+	if queue.Size() != 0 {
+		t.Fatalf("Publish() error; expected items without subscribers to be reclaimed, got size: %v", queue.Size())
+	}
+	a, b := queue.Subscribe(false), queue.Subscribe(false)
+	queue.Publish(1)
+	queue.Publish(2)
+	assertReads(t, a, 1, 2)
+	if queue.Size() != 2 {
+		t.Fatalf("Next() error; expected %v retained items, got: %v", 2, queue.Size())
+	}
+	b.Next()
+	if queue.Size() != 1 {
+		t.Fatalf("Next() error; expected %v retained item, got: %v", 1, queue.Size())
+	}
+	b.Unsubscribe()
+	if queue.Size() != 0 || queue.Subscribers() != 1 || !b.Closed() {
+		t.Errorf("Unsubscribe() error; expected unread items to be reclaimed, got size: %v", queue.Size())
+	}
+	if value, ok := b.Next(); ok {
+		t.Errorf("Next() failed: returned: %v, expected closed subscriber", value)
+	}
+}
+
+func TestBroadcastQueue_ReclaimRandom(t *testing.T) {
+	const retain, subscribers = 5, 20
+	queue := gost.NewBroadcastQueue(retain, 0, gost.DropOldest)
+	readers := make([]*gost.Subscriber, subscribers)
+	read := make([]int, subscribers) // amount of items read by each subscriber
+	for i := range readers {
+		readers[i] = queue.Subscribe(false)
+	}
+	published := 0
+	for step := 0; step < num; step++ {
+		if i := rand.Intn(subscribers + 1); i == subscribers {
+			queue.Publish(published)
+			published++
+		} else if value, ok := readers[i].Next(); ok {
+			if value != read[i] {
+				t.Fatalf("Next() failed: returned: %v, expected: %v", value, read[i])
+			}
+			read[i]++
+		}
+		oldest := published
+		for _, count := range read {
+			if count < oldest {
+				oldest = count
+			}
+		}
+		// Items are retained while unread by anyone, or while among the retain most recent ones.
+		expected := published - oldest
+		if kept := retain; expected < kept {
+			if published < kept {
+				kept = published
+			}
+			expected = kept
+		}
+		if queue.Size() != expected {
+			t.Fatalf("reclaim error; expected %v retained items, got: %v", expected, queue.Size())
+		}
+	}
+}
+
+func TestBroadcastQueue_Replay(t *testing.T) {
+	queue := gost.NewBroadcastQueue(3, 0, gost.DropOldest)
+	for i := 0; i < 5; i++ {
+		queue.Publish(i)
+	}
+	if queue.Size() != 3 {
+		t.Fatalf("Publish() error; expected %v retained items, got: %v", 3, queue.Size())
+	}
+	replaying, live := queue.Subscribe(true), queue.Subscribe(false)
+	queue.Publish(5)
+	assertReads(t, replaying, 2, 3, 4, 5)
+	assertReads(t, live, 5)
+	if queue.Size() != 3 {
+		t.Errorf("Next() error; expected %v retained items, got: %v", 3, queue.Size())
+	}
+}
+
+func TestBroadcastQueue_DropOldest(t *testing.T) {
+	queue := gost.NewBroadcastQueue(0, 3, gost.DropOldest)
+	subscriber := queue.Subscribe(false)
+	for i := 0; i < 5; i++ {
+		if err := queue.Publish(i); err != nil {
+			t.Fatalf("Publish() error; unexpected error: %v", err)
+		}
+	}
+	if subscriber.Lag() != 3 || subscriber.Dropped() != 2 || queue.Size() != 3 {
+		t.Fatalf("Publish() error; expected lag: %v and dropped: %v, got: %v and %v", 3, 2, subscriber.Lag(), subscriber.Dropped())
+	}
+	assertReads(t, subscriber, 2, 3, 4)
+}
+
+func TestBroadcastQueue_Disconnect(t *testing.T) {
+	queue := gost.NewBroadcastQueue(0, 2, gost.Disconnect)
+	slow, fast := queue.Subscribe(false), queue.Subscribe(false)
+	for i := 0; i < 3; i++ {
+		queue.Publish(i)
+		fast.Next()
+	}
+	if !slow.Closed() || fast.Closed() || queue.Subscribers() != 1 {
+		t.Fatalf("Publish() error; expected only the slow subscriber to be closed")
+	}
+	if queue.Size() != 0 {
+		t.Errorf("Publish() error; expected items to be reclaimed, got size: %v", queue.Size())
+	}
+}
+
+func TestBroadcastQueue_Reject(t *testing.T) {
+	queue := gost.NewBroadcastQueue(0, 2, gost.Reject)
+	subscriber := queue.Subscribe(false)
+	queue.Publish(0)
+	queue.Publish(1)
+	if err := queue.Publish(2); err == nil {
+		t.Fatalf("Publish() error; expected error while subscriber lags behind by the maximum lag")
+	}
+	subscriber.Next()
+	if err := queue.Publish(2); err != nil {
+		t.Fatalf("Publish() error; unexpected error: %v", err)
+	}
+	assertReads(t, subscriber, 1, 2)
+}
+
+/*
+BroadcastQueue Benchmark: measure publishing to a handful of subscribers which keep up
+*/
+
+func BenchmarkBroadcastQueue_Publish(b *testing.B) {
+	queue := gost.NewBroadcastQueue(0, 0, gost.DropOldest)
+	subscribers := []*gost.Subscriber{queue.Subscribe(false), queue.Subscribe(false), queue.Subscribe(false)}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		queue.Publish(i)
+		for _, subscriber := range subscribers {
+			subscriber.Next()
+		}
+	}
+}
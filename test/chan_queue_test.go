@@ -0,0 +1,184 @@
+package gost_test
+
+import (
+	"context"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/christat/gost/queue"
+	stacks "github.com/christat/gost/stack"
+)
+
+// test helper function; fails if the amount of goroutines does not go back to expected within a second.
+func assertNoLeak(t *testing.T, expected int) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > expected {
+		if time.Now().After(deadline) {
+			t.Fatalf("goroutine leak; expected %v goroutines, got: %v", expected, runtime.NumGoroutine())
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestChanQueue_Unbounded(t *testing.T) {
+	goroutines := runtime.NumGoroutine()
+	cq := gost.NewChanQueue(context.Background(), new(gost.NodeQueue))
+	// Sending never blocks for long, as values are buffered in the NodeQueue.
+	for i := 0; i < num; i++ {
+		cq.In() <- i
+	}
+	close(cq.In())
+	i := 0
+	for value := range cq.Out() {
+		if value != i {
+			t.Fatalf("Out() error; expected: %v, got: %v", i, value)
+		}
+		i++
+	}
+	if i != num {
+		t.Errorf("Out() error; expected %v values, got: %v", num, i)
+	}
+	<-cq.Done()
+	assertNoLeak(t, goroutines)
+}
+
+func TestChanQueue_Existing(t *testing.T) {
+	queue := gost.NewQueue(10)
+	queue.Enqueue("a")
+	cq := gost.NewChanQueue(context.Background(), queue)
+	cq.In() <- "b"
+	close(cq.In())
+	for _, expected := range []string{"a", "b"} {
+		if value := <-cq.Out(); value != expected {
+			t.Errorf("Out() error; expected: %v, got: %v", expected, value)
+		}
+	}
+	if _, ok := <-cq.Out(); ok {
+		t.Error("Out() error; expected channel to be closed once drained")
+	}
+}
+
+func TestChanQueue_Cancel(t *testing.T) {
+	goroutines := runtime.NumGoroutine()
+	ctx, cancel := context.WithCancel(context.Background())
+	queue := new(gost.NodeQueue)
+	cq := gost.NewChanQueue(ctx, queue)
+	for i := 0; i < 10; i++ {
+		cq.In() <- i
+	}
+	if value := <-cq.Out(); value != 0 {
+		t.Fatalf("Out() error; expected: %v, got: %v", 0, value)
+	}
+	cancel()
+	<-cq.Done()
+	if _, ok := <-cq.Out(); ok {
+		t.Error("Out() error; expected channel to be closed on cancellation")
+	}
+	// Undelivered values remain in the wrapped queue, in order.
+	if queue.Size() != 9 {
+		t.Fatalf("cancellation error; expected %v values left, got: %v", 9, queue.Size())
+	}
+	for i := 1; i < 10; i++ {
+		if value := queue.Dequeue(); value != i {
+			t.Fatalf("cancellation error; expected: %v, got: %v", i, value)
+		}
+	}
+	assertNoLeak(t, goroutines)
+}
+
+func TestChanQueue_SendAfterCancel(t *testing.T) {
+	goroutines := runtime.NumGoroutine()
+	ctx, cancel := context.WithCancel(context.Background())
+	cq := gost.NewChanQueue(ctx, new(gost.NodeQueue))
+	if err := cq.Send(0); err != nil {
+		t.Fatalf("Send() failed unexpectedly: %v", err)
+	}
+	cancel()
+	// A producer sending after cancellation must not be left blocked.
+	sent := make(chan error)
+	go func() { sent <- cq.Send(1) }()
+	select {
+	case err := <-sent:
+		if err == nil {
+			t.Error("Send() did not return error after cancellation")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Send() blocked after cancellation")
+	}
+	assertNoLeak(t, goroutines)
+}
+
+func TestChanStack(t *testing.T) {
+	goroutines := runtime.NumGoroutine()
+	ctx, cancel := context.WithCancel(context.Background())
+	stack := new(stacks.NodeStack)
+	stack.Push("a")
+	cq := gost.NewChanStack(ctx, stack)
+	cq.In() <- "b"
+	cq.In() <- "c"
+	// Values are delivered from the top of the stack, including the ones sent while another was being offered.
+	for _, expected := range []string{"c", "b"} {
+		if value := <-cq.Out(); value != expected {
+			t.Fatalf("Out() error; expected: %v, got: %v", expected, value)
+		}
+	}
+	cq.In() <- "d"
+	if value := <-cq.Out(); value != "d" {
+		t.Fatalf("Out() error; expected: %v, got: %v", "d", value)
+	}
+	cq.In() <- "e"
+	cancel()
+	<-cq.Done()
+	// Undelivered values remain in the wrapped stack, in order.
+	for _, expected := range []string{"e", "a"} {
+		if value := stack.Pop(); value != expected {
+			t.Fatalf("cancellation error; expected: %v, got: %v", expected, value)
+		}
+	}
+	if stack.Size() != 0 {
+		t.Errorf("cancellation error; expected %v values left, got: %v", 0, stack.Size())
+	}
+	assertNoLeak(t, goroutines)
+}
+
+func TestFromChan(t *testing.T) {
+	goroutines := runtime.NumGoroutine()
+	ch := make(chan interface{}, 3)
+	for i := 0; i < 3; i++ {
+		ch <- newVector(i)
+	}
+	close(ch)
+	cq := gost.FromChan(context.Background(), ch)
+	i := 0
+	for value := range cq.Out() {
+		if *(value.(*vector)) != *newVector(i) {
+			t.Fatalf("FromChan() error; expected: %v, got: %v", newVector(i), value)
+		}
+		i++
+	}
+	if i != 3 {
+		t.Errorf("FromChan() error; expected %v values, got: %v", 3, i)
+	}
+	<-cq.Done()
+	assertNoLeak(t, goroutines)
+}
+
+func TestFromChan_Live(t *testing.T) {
+	goroutines := runtime.NumGoroutine()
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := make(chan interface{})
+	// FromChan must return while ch is still open.
+	cq := gost.FromChan(ctx, ch)
+	for i := 0; i < 10; i++ {
+		ch <- i
+	}
+	if value := <-cq.Out(); value != 0 {
+		t.Fatalf("FromChan() error; expected: %v, got: %v", 0, value)
+	}
+	// Cancelling stops the forwarder even though ch is never closed.
+	cancel()
+	<-cq.Done()
+	assertNoLeak(t, goroutines)
+}
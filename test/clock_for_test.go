@@ -0,0 +1,71 @@
+package gost_test
+
+import (
+	"sync"
+	"time"
+)
+
+// fakeClock is a manually advanced Clock, used to test time-dependent queues deterministically.
+// It is safe for concurrent use, so that blocked consumers can be released from the test goroutine.
+type fakeClock struct {
+	mutex   sync.Mutex
+	now     time.Time
+	waiters []fakeWaiter
+}
+
+// fakeWaiter is a pending After() call.
+type fakeWaiter struct {
+	deadline time.Time
+	channel  chan time.Time
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	channel := make(chan time.Time, 1)
+	if d <= 0 {
+		channel <- c.now
+		return channel
+	}
+	c.waiters = append(c.waiters, fakeWaiter{deadline: c.now.Add(d), channel: channel})
+	return channel
+}
+
+// Advance moves the clock forward, firing the After() channels whose deadline has been reached.
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.now = c.now.Add(d)
+	pending := c.waiters[:0]
+	for _, waiter := range c.waiters {
+		if waiter.deadline.After(c.now) {
+			pending = append(pending, waiter)
+		} else {
+			waiter.channel <- c.now
+		}
+	}
+	c.waiters = pending
+}
+
+// WaitForWaiters blocks until at least n After() calls are pending.
+func (c *fakeClock) WaitForWaiters(n int) {
+	for {
+		c.mutex.Lock()
+		pending := len(c.waiters)
+		c.mutex.Unlock()
+		if pending >= n {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
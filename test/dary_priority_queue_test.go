@@ -0,0 +1,156 @@
+package gost_test
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/christat/gost/queue"
+)
+
+func TestDaryPriorityQueue_Dequeue(t *testing.T) {
+	pq := gost.DaryPriorityQueue{}
+	pq.Enqueue("a", 0)
+	pq.Enqueue("b", 5)
+	pq.Enqueue("c", 10)
+	pq.Enqueue("d", 5)
+	for _, expected := range []string{"c", "b", "d", "a"} {
+		if value := pq.Dequeue(); value != expected {
+			t.Errorf("Dequeue() failed: returned: %v, expected: %v", value, expected)
+		}
+	}
+	if pq.Size() != 0 || pq.Dequeue() != nil {
+		t.Error("Dequeue() failed: DaryPriorityQueue should be empty")
+	}
+}
+
+func TestDaryPriorityQueue_Random(t *testing.T) {
+	for _, arity := range []int{2, 3, 4, 8} {
+		for _, min := range []bool{false, true} {
+			pq := gost.NewDaryPriorityQueue(arity)
+			if min {
+				pq = gost.NewDaryMinPriorityQueue(arity)
+			}
+			type entry struct {
+				value    int
+				priority float64
+			}
+			var model []entry
+			random := rand.New(rand.NewSource(42))
+			for i := 0; i < num; i++ {
+				if random.Intn(2) == 0 || len(model) == 0 {
+					priority := float64(random.Intn(100))
+					pq.Enqueue(i, priority)
+					model = append(model, entry{value: i, priority: priority})
+					continue
+				}
+				sort.SliceStable(model, func(a, b int) bool {
+					if min {
+						return model[a].priority < model[b].priority
+					}
+					return model[a].priority > model[b].priority
+				})
+				if value := pq.Dequeue(); value != model[0].value {
+					t.Fatalf("Dequeue() failed with arity %v (min: %v): returned: %v, expected: %v", arity, min, value, model[0].value)
+				}
+				model = model[1:]
+			}
+		}
+	}
+}
+
+// backendQueue is the method set of PriorityQueue and MinPriorityQueue, used to compare their backends.
+type backendQueue interface {
+	Enqueue(item interface{}, priority float64)
+	EnqueueAll(items []interface{}, priorities []float64) error
+	Dequeue() interface{}
+	Size() int
+}
+
+func TestPriorityQueue_WithArity(t *testing.T) {
+	for _, arity := range []int{2, 4, 8} {
+		pairs := map[string][2]backendQueue{
+			"PriorityQueue":    {gost.NewPriorityQueue(), gost.NewPriorityQueueWithArity(arity)},
+			"MinPriorityQueue": {gost.NewMinPriorityQueue(), gost.NewMinPriorityQueueWithArity(arity)},
+		}
+		for name, pair := range pairs {
+			binary, dary := pair[0], pair[1]
+			random := rand.New(rand.NewSource(42))
+			for i := 0; i < num; i++ {
+				switch operation := random.Intn(8); {
+				case operation == 0:
+					items, priorities := make([]interface{}, random.Intn(50)), make([]float64, 0, 50)
+					for j := range items {
+						items[j] = i*100 + j
+						priorities = append(priorities, float64(random.Intn(20)))
+					}
+					binary.EnqueueAll(items, priorities)
+					dary.EnqueueAll(items, priorities)
+				case operation < 4:
+					priority := float64(random.Intn(20))
+					binary.Enqueue(i, priority)
+					dary.Enqueue(i, priority)
+				default:
+					if expected, value := binary.Dequeue(), dary.Dequeue(); value != expected {
+						t.Fatalf("%v with arity %v: Dequeue() failed: returned: %v, expected: %v", name, arity, value, expected)
+					}
+				}
+				if dary.Size() != binary.Size() {
+					t.Fatalf("%v with arity %v: Size() failed: returned: %v, expected: %v", name, arity, dary.Size(), binary.Size())
+				}
+			}
+		}
+	}
+}
+
+/*
+DaryPriorityQueue Benchmark:
+The following methods are meant to put the struct of arrays d-ary heap to the test against the container/heap
+based PriorityQueue, filling and subsequently emptying the structure with 1K to 10M randomly prioritized items.
+*/
+
+var benchmarkSizes = []int{1000, 100000, bigNum, 10 * bigNum}
+
+func BenchmarkPriorityQueue_Sizes(b *testing.B) {
+	for _, size := range benchmarkSizes {
+		priorities := benchmarkPriorities(size)
+		b.Run(fmt.Sprint(size), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				pq := gost.NewPriorityQueue()
+				for j, priority := range priorities {
+					pq.Enqueue(j, priority)
+				}
+				for pq.Size() > 0 {
+					pq.Dequeue()
+				}
+			}
+		})
+	}
+}
+
+// benchmark helper function; measures PriorityQueue with the d-ary heap backend of the given arity.
+func benchmarkDaryPriorityQueueSizes(arity int, b *testing.B) {
+	for _, size := range benchmarkSizes {
+		priorities := benchmarkPriorities(size)
+		b.Run(fmt.Sprint(size), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				pq := gost.NewPriorityQueueWithArity(arity)
+				for j, priority := range priorities {
+					pq.Enqueue(j, priority)
+				}
+				for pq.Size() > 0 {
+					pq.Dequeue()
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkDaryPriorityQueue_Sizes4(b *testing.B) {
+	benchmarkDaryPriorityQueueSizes(4, b)
+}
+
+func BenchmarkDaryPriorityQueue_Sizes8(b *testing.B) {
+	benchmarkDaryPriorityQueueSizes(8, b)
+}
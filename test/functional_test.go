@@ -0,0 +1,116 @@
+package gost_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/christat/gost/list"
+	queues "github.com/christat/gost/queue"
+	stacks "github.com/christat/gost/stack"
+)
+
+// test helper function; returns a NodeList holding the integers in [0, size).
+func generateIntList(size int) *gost.NodeList {
+	list := new(gost.NodeList)
+	for i := 0; i < size; i++ {
+		list.Append(i)
+	}
+	return list
+}
+
+func isEven(value interface{}) bool { return value.(int)%2 == 0 }
+
+func TestFunctional_MapFilterReduce(t *testing.T) {
+	list := generateIntList(10)
+	squares := gost.Map(list.Cursor(), func(value interface{}) interface{} { return value.(int) * value.(int) })
+	assertList(t, squares, 0, 1, 4, 9, 16, 25, 36, 49, 64, 81)
+	evens := gost.Filter(squares.Cursor(), isEven)
+	assertList(t, evens, 0, 4, 16, 36, 64)
+	sum := gost.Reduce(evens.Cursor(), 0, func(accumulated, value interface{}) interface{} {
+		return accumulated.(int) + value.(int)
+	})
+	if sum != 120 {
+		t.Errorf("Reduce() error; expected: %v, got: %v", 120, sum)
+	}
+	assertList(t, list, 0, 1, 2, 3, 4, 5, 6, 7, 8, 9)
+}
+
+func TestFunctional_Lazy(t *testing.T) {
+	calls := 0
+	square := func(value interface{}) interface{} {
+		calls++
+		return value.(int) * value.(int)
+	}
+	it := gost.LazyFilter(gost.LazyMap(generateIntList(num).Cursor(), square), func(value interface{}) bool {
+		return value.(int) > 50
+	})
+	if value, found := gost.Find(it, isEven); !found || value != 64 {
+		t.Fatalf("Find() error; expected: %v, got: %v", 64, value)
+	}
+	if calls != 9 {
+		t.Errorf("LazyMap() error; expected mapper to be called %v times, got: %v", 9, calls)
+	}
+	if it.Next(); it.Value() != 81 {
+		t.Errorf("LazyFilter() error; expected to resume with: %v, got: %v", 81, it.Value())
+	}
+}
+
+func TestFunctional_Search(t *testing.T) {
+	words := gost.Collect(gost.NewSliceIterator([]interface{}{"Go", "data", "Structures"}))
+	caseless := func(a, b interface{}) bool { return strings.EqualFold(a.(string), b.(string)) }
+	if index := gost.IndexOf(words.Cursor(), "structures", nil); index != -1 {
+		t.Errorf("IndexOf() error; expected: %v, got: %v", -1, index)
+	}
+	if index := gost.IndexOf(words.Cursor(), "structures", caseless); index != 2 {
+		t.Errorf("IndexOf() error; expected: %v, got: %v", 2, index)
+	}
+	if !gost.Contains(words.Cursor(), "data", nil) || gost.Contains(words.Cursor(), "list", caseless) {
+		t.Error("Contains() error; unexpected result")
+	}
+	list := generateIntList(10)
+	if !gost.Any(list.Cursor(), isEven) || gost.All(list.Cursor(), isEven) {
+		t.Error("Any()/All() error; unexpected result")
+	}
+	if gost.Any(new(gost.NodeList).Cursor(), isEven) || !gost.All(new(gost.NodeList).Cursor(), isEven) {
+		t.Error("Any()/All() error; unexpected result on empty list")
+	}
+	if _, found := gost.Find(list.Cursor(), func(value interface{}) bool { return value.(int) > 9 }); found {
+		t.Error("Find() error; expected no value to be found")
+	}
+}
+
+func TestFunctional_Grouping(t *testing.T) {
+	evens, odds := gost.Partition(generateIntList(7).Cursor(), isEven)
+	assertList(t, evens, 0, 2, 4, 6)
+	assertList(t, odds, 1, 3, 5)
+	groups := gost.GroupBy(generateIntList(7).Cursor(), func(value interface{}) interface{} { return value.(int) % 3 })
+	if len(groups) != 3 {
+		t.Fatalf("GroupBy() error; expected %v groups, got: %v", 3, len(groups))
+	}
+	assertList(t, groups[0], 0, 3, 6)
+	assertList(t, groups[1], 1, 4)
+	assertList(t, groups[2], 2, 5)
+}
+
+func TestFunctional_Containers(t *testing.T) {
+	nodeStack, sliceStack := new(stacks.NodeStack), stacks.NewStack(10)
+	nodeQueue, sliceQueue := new(queues.NodeQueue), queues.NewQueue(10)
+	for i := 0; i < 5; i++ {
+		nodeStack.Push(i)
+		sliceStack.Push(i)
+		nodeQueue.Enqueue(i)
+		sliceQueue.Enqueue(i)
+	}
+	assertList(t, gost.Collect(nodeStack.Iterator()), 4, 3, 2, 1, 0)
+	assertList(t, gost.Collect(sliceStack.Iterator()), 4, 3, 2, 1, 0)
+	assertList(t, gost.Collect(nodeQueue.Iterator()), 0, 1, 2, 3, 4)
+	assertList(t, gost.Collect(sliceQueue.Iterator()), 0, 1, 2, 3, 4)
+	if nodeStack.Size() != 5 || sliceQueue.Size() != 5 {
+		t.Fatal("Iterator() error; expected contents to be left in place")
+	}
+	assertList(t, gost.Filter(stacks.Drain(sliceStack), isEven), 4, 2, 0)
+	assertList(t, gost.Filter(queues.Drain(nodeQueue), isEven), 0, 2, 4)
+	if sliceStack.Size() != 0 || nodeQueue.Size() != 0 {
+		t.Error("Drain() error; expected containers to be emptied")
+	}
+}
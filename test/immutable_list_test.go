@@ -0,0 +1,98 @@
+package gost_test
+
+import (
+	"testing"
+
+	"github.com/christat/gost/list"
+)
+
+// test helper function; checks that list holds exactly the expected values.
+func assertValues(t *testing.T, list *gost.ImmutableList, expected ...interface{}) {
+	t.Helper()
+	values := list.Values()
+	if len(values) != len(expected) || list.Size() != len(expected) {
+		t.Fatalf("Values() error; expected: %v, got: %v (size %v)", expected, values, list.Size())
+	}
+	for i := range expected {
+		if values[i] != expected[i] {
+			t.Fatalf("Values() error; expected: %v, got: %v", expected, values)
+		}
+	}
+}
+
+func TestImmutableList_Empty(t *testing.T) {
+	var list *gost.ImmutableList
+	if list.Size() != 0 || list.Head() != nil || list.Tail() != nil {
+		t.Fatalf("nil list should behave as empty")
+	}
+	if _, err := list.Retrieve(0); err == nil {
+		t.Error("Retrieve() did not return error on empty list")
+	}
+	if _, _, err := list.Remove(0); err == nil {
+		t.Error("Remove() did not return error on empty list")
+	}
+	assertValues(t, list.Append(1), 1)
+}
+
+func TestImmutableList_Versions(t *testing.T) {
+	base := gost.NewImmutableList(1, 2, 3)
+	prepended := base.Prepend(0)
+	appended := base.Append(4)
+	added, err := base.Add(1, 9)
+	if err != nil {
+		t.Fatalf("Add() failed unexpectedly: %v", err)
+	}
+	set, err := base.Set(-1, 7)
+	if err != nil {
+		t.Fatalf("Set() failed unexpectedly: %v", err)
+	}
+	removed, value, err := base.Remove(1)
+	if err != nil || value != 2 {
+		t.Fatalf("Remove() failed: returned: %v, expected: %v", value, 2)
+	}
+	assertValues(t, base, 1, 2, 3)
+	assertValues(t, prepended, 0, 1, 2, 3)
+	assertValues(t, appended, 1, 2, 3, 4)
+	assertValues(t, added, 1, 9, 2, 3)
+	assertValues(t, set, 1, 2, 7)
+	assertValues(t, removed, 1, 3)
+	assertValues(t, base.Tail(), 2, 3)
+	if _, err := base.Add(5, 0); err == nil {
+		t.Error("Add() did not return error on exceeding size index")
+	}
+	if value, err := base.Retrieve(-1); err != nil || value != 3 {
+		t.Errorf("Retrieve() failed: returned: %v, expected: %v", value, 3)
+	}
+}
+
+func TestImmutableList_Snapshots(t *testing.T) {
+	var list *gost.ImmutableList
+	snapshots := make([]*gost.ImmutableList, num)
+	for i := 0; i < num; i++ {
+		list = list.Prepend(newVector(i))
+		snapshots[i] = list
+	}
+	for i, snapshot := range snapshots {
+		if snapshot.Size() != i+1 || *(snapshot.Head().(*vector)) != *newVector(i) {
+			t.Fatalf("Prepend() error; snapshot %v changed: size %v, head %v", i, snapshot.Size(), snapshot.Head())
+		}
+	}
+	last := snapshots[num-1]
+	for i := num - 1; i > 0; i-- {
+		last = last.Tail()
+		if last.Head() != snapshots[i-1].Head() {
+			t.Fatalf("Tail() error; expected tails to be shared with older snapshots")
+		}
+	}
+}
+
+/*
+ImmutableList Benchmark: measure prepending with a snapshot kept for every version
+*/
+
+func BenchmarkImmutableList_Prepend(b *testing.B) {
+	var list *gost.ImmutableList
+	for i := 0; i < b.N; i++ {
+		list = list.Prepend(i)
+	}
+}
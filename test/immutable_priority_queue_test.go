@@ -0,0 +1,91 @@
+package gost_test
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/christat/gost/queue"
+)
+
+func TestImmutablePriorityQueue_Order(t *testing.T) {
+	max, min := gost.NewImmutablePriorityQueue(), gost.NewImmutableMinPriorityQueue()
+	priorities := make([]float64, num)
+	for i := range priorities {
+		priorities[i] = float64(rand.Intn(num / 10))
+		max = max.Enqueue(i, priorities[i])
+		min = min.Enqueue(i, priorities[i])
+	}
+	// Items are expected in priority order, and in insertion order among equal priorities.
+	expected := make([]int, num)
+	for i := range expected {
+		expected[i] = i
+	}
+	sort.SliceStable(expected, func(i, j int) bool { return priorities[expected[i]] < priorities[expected[j]] })
+	for _, index := range expected {
+		var value interface{}
+		if value, min = min.Dequeue(); value != index {
+			t.Fatalf("Dequeue() failed: returned: %v, expected: %v", value, index)
+		}
+	}
+	sort.SliceStable(expected, func(i, j int) bool { return priorities[expected[i]] > priorities[expected[j]] })
+	for _, index := range expected {
+		var value interface{}
+		if value, max = max.Dequeue(); value != index {
+			t.Fatalf("Dequeue() failed: returned: %v, expected: %v", value, index)
+		}
+	}
+	if value, rest := max.Dequeue(); value != nil || rest != max {
+		t.Errorf("Dequeue() did not return nil and the same queue on empty queue")
+	}
+	// Dequeuing from a drained min. queue keeps it a min. queue.
+	_, min = min.Dequeue()
+	if value, _ := min.Enqueue("b", 2).Enqueue("a", 1).Dequeue(); value != "a" {
+		t.Errorf("Dequeue() failed: returned: %v, expected: %v", value, "a")
+	}
+}
+
+func TestImmutablePriorityQueue_Forks(t *testing.T) {
+	var base *gost.ImmutablePriorityQueue
+	base = base.Enqueue("a", 2).Enqueue("b", 5).Enqueue("c", 2)
+	left := base.Enqueue("left", 9)
+	_, right := base.Dequeue()
+	right = right.Enqueue("right", 2)
+	assertPriorityOrder(t, base, "b", "a", "c")
+	assertPriorityOrder(t, left, "left", "b", "a", "c")
+	assertPriorityOrder(t, right, "a", "c", "right")
+	assertPriorityOrder(t, base.Meld(right), "b", "a", "a", "c", "c", "right")
+}
+
+// test helper function; dequeues every item of queue and checks they match the expected ones in order.
+func assertPriorityOrder(t *testing.T, queue *gost.ImmutablePriorityQueue, expected ...interface{}) {
+	t.Helper()
+	if queue.Size() != len(expected) {
+		t.Fatalf("Size() error; expected: %v, got: %v", len(expected), queue.Size())
+	}
+	for _, item := range expected {
+		if queue.Peek() != item {
+			t.Fatalf("Peek() failed: returned: %v, expected: %v", queue.Peek(), item)
+		}
+		var value interface{}
+		if value, queue = queue.Dequeue(); value != item {
+			t.Fatalf("Dequeue() failed: returned: %v, expected: %v", value, item)
+		}
+	}
+}
+
+/*
+ImmutablePriorityQueue Benchmark: measure forking a queue of num items and modifying the fork
+*/
+
+func BenchmarkImmutablePriorityQueue_Fork(b *testing.B) {
+	queue := gost.NewImmutablePriorityQueue()
+	for i := 0; i < num; i++ {
+		queue = queue.Enqueue(i, float64(i))
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, fork := queue.Dequeue()
+		fork.Enqueue(i, float64(i))
+	}
+}
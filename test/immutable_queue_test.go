@@ -0,0 +1,117 @@
+package gost_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/christat/gost/queue"
+)
+
+// test helper function; dequeues every item of queue and checks they match the expected ones in order.
+func assertDrains(t *testing.T, queue *gost.ImmutableQueue, expected ...interface{}) {
+	t.Helper()
+	if queue.Size() != len(expected) {
+		t.Fatalf("Size() error; expected: %v, got: %v", len(expected), queue.Size())
+	}
+	for _, item := range expected {
+		var value interface{}
+		value, queue = queue.Dequeue()
+		if value != item {
+			t.Fatalf("Dequeue() failed: returned: %v, expected: %v", value, item)
+		}
+	}
+	if value, rest := queue.Dequeue(); value != nil || rest != queue {
+		t.Fatalf("Dequeue() did not return nil and the same queue on empty queue")
+	}
+}
+
+func TestImmutableQueue_FIFO(t *testing.T) {
+	var queue *gost.ImmutableQueue
+	dequeued := 0
+	for i := 0; i < num; i++ {
+		queue = queue.Enqueue(i)
+		if i%3 == 0 {
+			var value interface{}
+			value, queue = queue.Dequeue()
+			if value != dequeued {
+				t.Fatalf("Dequeue() failed: returned: %v, expected: %v", value, dequeued)
+			}
+			dequeued++
+		}
+	}
+	var expected []interface{}
+	for i := dequeued; i < num; i++ {
+		expected = append(expected, i)
+	}
+	assertDrains(t, queue, expected...)
+}
+
+func TestImmutableQueue_Snapshots(t *testing.T) {
+	snapshots := make([]*gost.ImmutableQueue, 0, 100)
+	var queue *gost.ImmutableQueue
+	for i := 0; i < 100; i++ {
+		snapshots = append(snapshots, queue)
+		queue = queue.Enqueue(i)
+		if i%4 == 3 {
+			_, queue = queue.Dequeue()
+		}
+	}
+	// Replaying any snapshot yields its own contents, regardless of what other versions did since.
+	for step, snapshot := range snapshots {
+		var expected []interface{}
+		for i := 0; i < step; i++ {
+			expected = append(expected, i)
+		}
+		expected = expected[step/4:]
+		assertDrains(t, snapshot, expected...)
+		assertDrains(t, snapshot.Enqueue("x"), append(expected, "x")...)
+	}
+}
+
+func TestImmutableQueue_Branches(t *testing.T) {
+	base := new(gost.ImmutableQueue).Enqueue(1).Enqueue(2).Enqueue(3)
+	_, base = base.Dequeue()
+	left, right := base.Enqueue("left"), base.Enqueue("right")
+	assertDrains(t, left, 2, 3, "left")
+	assertDrains(t, right, 2, 3, "right")
+	assertDrains(t, base, 2, 3)
+	if base.Peek() != 2 {
+		t.Errorf("Peek() failed: returned: %v, expected: %v", base.Peek(), 2)
+	}
+}
+
+func TestImmutableQueue_ConcurrentReaders(t *testing.T) {
+	var queue *gost.ImmutableQueue
+	for i := 0; i < num; i++ {
+		queue = queue.Enqueue(i)
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func(queue *gost.ImmutableQueue) {
+			defer wg.Done()
+			for i := 0; i < num; i++ {
+				var value interface{}
+				if value, queue = queue.Dequeue(); value != i {
+					t.Errorf("Dequeue() failed: returned: %v, expected: %v", value, i)
+					return
+				}
+			}
+		}(queue)
+	}
+	wg.Wait()
+}
+
+/*
+ImmutableQueue Benchmark: measure enqueuing and de-queuing while keeping every version
+*/
+
+func BenchmarkImmutableQueue_EnqueueDequeue(b *testing.B) {
+	var queue *gost.ImmutableQueue
+	for i := 0; i < b.N; i++ {
+		queue = queue.Enqueue(i)
+		if i%2 == 1 {
+			_, queue = queue.Dequeue()
+		}
+	}
+}
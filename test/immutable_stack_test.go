@@ -0,0 +1,43 @@
+package gost_test
+
+import (
+	"testing"
+
+	"github.com/christat/gost/stack"
+)
+
+func TestImmutableStack_PushPop(t *testing.T) {
+	var empty *gost.ImmutableStack
+	if value, rest := empty.Pop(); value != nil || rest != empty {
+		t.Fatalf("Pop() did not return nil and the same stack on empty stack")
+	}
+	stack := empty
+	for i := 0; i < num; i++ {
+		stack = stack.Push(newVector(i))
+	}
+	if stack.Size() != num || empty.Size() != 0 {
+		t.Fatalf("Push() error; expected size: %v, got: %v", num, stack.Size())
+	}
+	for i := num - 1; i >= 0; i-- {
+		var value interface{}
+		value, stack = stack.Pop()
+		if *(value.(*vector)) != *newVector(i) {
+			t.Fatalf("Pop() failed: returned: %v, expected: %v", value, newVector(i))
+		}
+	}
+	if stack.Size() != 0 || stack.Peek() != nil {
+		t.Errorf("Pop() error; expected empty stack, got size: %v", stack.Size())
+	}
+}
+
+func TestImmutableStack_Backtracking(t *testing.T) {
+	base := new(gost.ImmutableStack).Push("a").Push("b")
+	left, right := base.Push("left"), base.Push("right")
+	if left.Peek() != "left" || right.Peek() != "right" || base.Peek() != "b" {
+		t.Fatalf("Push() error; versions should not affect each other")
+	}
+	_, popped := left.Pop()
+	if popped.Peek() != "b" || popped.Size() != base.Size() {
+		t.Errorf("Pop() error; expected to get back to the base version, got: %v", popped.Peek())
+	}
+}
@@ -0,0 +1,135 @@
+package gost_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/christat/gost/queue"
+)
+
+type edge struct {
+	to     int
+	weight float64
+}
+
+// test graph used to run Dijkstra's algorithm over both indexed queues.
+var graph = [][]edge{
+	0: {{1, 4}, {2, 1}},
+	1: {{3, 1}},
+	2: {{1, 2}, {3, 5}},
+	3: {{4, 3}},
+	4: {},
+	5: {{0, 1}},
+}
+
+var distances = []float64{0, 3, 1, 4, 7, math.Inf(1)}
+
+func TestIndexedMinPriorityQueue_Dijkstra(t *testing.T) {
+	pq := gost.NewIndexedMinPriorityQueue[int]()
+	dist := make([]float64, len(graph))
+	for i := range dist {
+		dist[i] = math.Inf(1)
+	}
+	dist[0] = 0
+	pq.Upsert(0, 0)
+	for pq.Size() > 0 {
+		key, priority, err := pq.Dequeue()
+		if err != nil {
+			t.Fatalf("Dequeue() failed unexpectedly: %v", err)
+		}
+		for _, e := range graph[key] {
+			if d := priority + e.weight; d < dist[e.to] {
+				dist[e.to] = d
+				pq.Upsert(e.to, d)
+			}
+		}
+	}
+	for i := range distances {
+		if dist[i] != distances[i] {
+			t.Errorf("Dijkstra error; distance to %v expected: %v, got: %v", i, distances[i], dist[i])
+		}
+	}
+}
+
+func TestIntIndexedMinPriorityQueue_Dijkstra(t *testing.T) {
+	pq := gost.NewIntIndexedMinPriorityQueue(len(graph))
+	dist := make([]float64, len(graph))
+	for i := range dist {
+		dist[i] = math.Inf(1)
+		pq.Enqueue(i, dist[i])
+	}
+	pq.DecreaseKey(0, 0)
+	dist[0] = 0
+	for pq.Size() > 0 {
+		key, priority, _ := pq.Dequeue()
+		for _, e := range graph[key] {
+			if d := priority + e.weight; d < dist[e.to] {
+				dist[e.to] = d
+				if err := pq.DecreaseKey(e.to, d); err != nil {
+					t.Fatalf("DecreaseKey() failed unexpectedly: %v", err)
+				}
+			}
+		}
+	}
+	for i := range distances {
+		if dist[i] != distances[i] {
+			t.Errorf("Dijkstra error; distance to %v expected: %v, got: %v", i, distances[i], dist[i])
+		}
+	}
+}
+
+func TestIndexedMinPriorityQueue_Keys(t *testing.T) {
+	pq := gost.NewIndexedMinPriorityQueue[string]()
+	if _, _, err := pq.Dequeue(); err == nil {
+		t.Error("Dequeue() did not return error on empty queue")
+	}
+	pq.Enqueue("a", 5)
+	pq.Enqueue("b", 3)
+	pq.Enqueue("c", 3)
+	if err := pq.Enqueue("a", 1); err == nil {
+		t.Error("Enqueue() did not return error on queued key")
+	}
+	if !pq.Contains("a") || pq.Contains("d") {
+		t.Error("Contains() failed")
+	}
+	if priority, err := pq.PriorityOf("a"); err != nil || priority != 5 {
+		t.Errorf("PriorityOf() error; expected: %v, got: %v (%v)", 5, priority, err)
+	}
+	if _, err := pq.PriorityOf("d"); err == nil {
+		t.Error("PriorityOf() did not return error on missing key")
+	}
+	if err := pq.DecreaseKey("a", 6); err == nil {
+		t.Error("DecreaseKey() did not return error on higher priority")
+	}
+	if err := pq.DecreaseKey("d", 0); err == nil {
+		t.Error("DecreaseKey() did not return error on missing key")
+	}
+	pq.DecreaseKey("a", 3)
+	if priority, err := pq.Remove("c"); err != nil || priority != 3 {
+		t.Errorf("Remove() error; expected: %v, got: %v (%v)", 3, priority, err)
+	}
+	for _, expected := range []string{"a", "b"} {
+		if key, _, _ := pq.Dequeue(); key != expected {
+			t.Errorf("Dequeue() error; expected: %v, got: %v", expected, key)
+		}
+	}
+	if pq.Size() != 0 || pq.Contains("a") {
+		t.Error("Dequeue() failed: queue should be empty")
+	}
+}
+
+func TestIntIndexedMinPriorityQueue_Bounds(t *testing.T) {
+	pq := gost.NewIntIndexedMinPriorityQueue(3)
+	if err := pq.Enqueue(3, 0); err == nil {
+		t.Error("Enqueue() did not return error on out of bounds key")
+	}
+	if err := pq.Upsert(-1, 0); err == nil {
+		t.Error("Upsert() did not return error on out of bounds key")
+	}
+	if pq.Contains(5) {
+		t.Error("Contains() failed on out of bounds key")
+	}
+	if _, err := pq.Remove(1); err == nil {
+		t.Error("Remove() did not return error on missing key")
+	}
+}
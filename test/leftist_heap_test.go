@@ -0,0 +1,60 @@
+package gost_test
+
+import (
+	"testing"
+
+	"github.com/christat/gost/queue"
+)
+
+func TestLeftistHeap_Dequeue(t *testing.T) {
+	testMergeableHeapDequeue(t, gost.NewLeftistHeap())
+}
+
+func TestLeftistHeap_UpdatePriority(t *testing.T) {
+	testMergeableHeapUpdatePriority(t, gost.NewLeftistHeap())
+}
+
+func TestLeftistHeap_Random(t *testing.T) {
+	testMergeableHeapRandom(t, gost.NewLeftistHeap())
+}
+
+func TestLeftistHeap_Meld(t *testing.T) {
+	a, b := gost.NewLeftistHeap(), gost.NewLeftistHeap()
+	testMergeableHeapMeld(t, a, b, func() { a.Meld(b) })
+}
+
+func benchmarkLeftistHeapBasicTest(size int, b *testing.B) {
+	priorities := benchmarkPriorities(size)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		heap := gost.NewLeftistHeap()
+		for j, priority := range priorities {
+			heap.Enqueue(newVector(j), priority)
+		}
+		for heap.Size() > 0 {
+			heap.Dequeue()
+		}
+	}
+}
+
+func BenchmarkLeftistHeap_BasicTest1000(b *testing.B) {
+	benchmarkLeftistHeapBasicTest(1000, b)
+}
+
+func BenchmarkLeftistHeap_BasicTest100000(b *testing.B) {
+	benchmarkLeftistHeapBasicTest(100000, b)
+}
+
+func BenchmarkLeftistHeap_Meld(b *testing.B) {
+	priorities := benchmarkPriorities(num)
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		first, second := gost.NewLeftistHeap(), gost.NewLeftistHeap()
+		for j, priority := range priorities {
+			first.Enqueue(newVector(j), priority)
+			second.Enqueue(newVector(j), priority)
+		}
+		b.StartTimer()
+		first.Meld(second)
+	}
+}
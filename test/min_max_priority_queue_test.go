@@ -0,0 +1,102 @@
+package gost_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/christat/gost/queue"
+)
+
+func TestMinMaxPriorityQueue_Len(t *testing.T) {
+	queue := gost.MinMaxPriorityQueue{}
+	if queue.Size() != 0 {
+		t.Error("Size() failed to return empty queue length")
+	}
+	for i := 0; i < 10; i++ {
+		queue.Enqueue(newVector(i), 1)
+	}
+	if queue.Size() != 10 {
+		t.Errorf("Size() length: %v, expected: %v", queue.Size(), 10)
+	}
+}
+
+func TestMinMaxPriorityQueue_Dequeue(t *testing.T) {
+	pq := gost.NewMinMaxPriorityQueue()
+	pq.Enqueue("a", 0)
+	pq.Enqueue("b", 5)
+	pq.Enqueue("c", 10)
+	pq.Enqueue("d", 5)
+	pq.Enqueue("e", 0)
+
+	if value := pq.PeekMax(); value != "c" {
+		t.Errorf("PeekMax() failed: returned: %v, expected: %v", value, "c")
+	}
+	if value := pq.PeekMin(); value != "a" {
+		t.Errorf("PeekMin() failed: returned: %v, expected: %v", value, "a")
+	}
+	for _, expected := range []string{"c", "b"} {
+		if value := pq.DequeueMax(); value != expected {
+			t.Errorf("DequeueMax() failed: returned: %v, expected: %v", value, expected)
+		}
+	}
+	for _, expected := range []string{"a", "e", "d"} {
+		if value := pq.DequeueMin(); value != expected {
+			t.Errorf("DequeueMin() failed: returned: %v, expected: %v", value, expected)
+		}
+	}
+	if pq.Size() != 0 {
+		t.Error("Dequeue() failed: MinMaxPriorityQueue should be empty")
+	}
+	if pq.DequeueMax() != nil || pq.DequeueMin() != nil || pq.PeekMax() != nil || pq.PeekMin() != nil {
+		t.Error("Dequeue() failed: MinMaxPriorityQueue returned non-nil value when empty")
+	}
+}
+
+func TestMinMaxPriorityQueue_Random(t *testing.T) {
+	type entry struct {
+		value    int
+		priority float64
+	}
+	// brute-force model: a slice kept in insertion order, scanned for the first extreme priority.
+	var model []entry
+	extreme := func(max bool) int {
+		best := 0
+		for i, e := range model {
+			if (max && e.priority > model[best].priority) || (!max && e.priority < model[best].priority) {
+				best = i
+			}
+		}
+		return best
+	}
+	pq := gost.NewMinMaxPriorityQueue()
+	random := rand.New(rand.NewSource(42))
+	for i := 0; i < num; i++ {
+		if random.Intn(3) > 0 || len(model) == 0 {
+			priority := float64(random.Intn(50))
+			pq.Enqueue(i, priority)
+			model = append(model, entry{value: i, priority: priority})
+			continue
+		}
+		max := random.Intn(2) == 0
+		index := extreme(max)
+		var value interface{}
+		if max {
+			if peek := pq.PeekMax(); peek != model[index].value {
+				t.Fatalf("PeekMax() failed: returned: %v, expected: %v", peek, model[index].value)
+			}
+			value = pq.DequeueMax()
+		} else {
+			if peek := pq.PeekMin(); peek != model[index].value {
+				t.Fatalf("PeekMin() failed: returned: %v, expected: %v", peek, model[index].value)
+			}
+			value = pq.DequeueMin()
+		}
+		if value != model[index].value {
+			t.Fatalf("Dequeue() failed: returned: %v, expected: %v", value, model[index].value)
+		}
+		model = append(model[:index], model[index+1:]...)
+		if pq.Size() != len(model) {
+			t.Fatalf("Size() failed: returned: %v, expected: %v", pq.Size(), len(model))
+		}
+	}
+}
@@ -73,3 +73,26 @@ func TestMinPriorityQueue_Pop(t *testing.T) {
 		t.Error("Dequeue() failed: MinPriorityQueue returned non-nil value when empty")
 	}
 }
+
+func TestMinPriorityQueue_EnqueueAll(t *testing.T) {
+	if _, err := gost.NewMinPriorityQueueFrom(nil, []float64{1}); err == nil {
+		t.Error("NewMinPriorityQueueFrom() did not return error on mismatched lengths")
+	}
+	pq, err := gost.NewMinPriorityQueueFrom([]interface{}{"a", "b", "c", "d"}, []float64{0, 5, 10, 5})
+	if err != nil {
+		t.Fatalf("NewMinPriorityQueueFrom() failed unexpectedly: %v", err)
+	}
+	pq.EnqueueAll([]interface{}{"e"}, []float64{5})
+	pq.EnqueueAll([]interface{}{"f", "g", "h", "i", "j"}, []float64{5, 10, 0, 1, 1})
+	expected := []interface{}{"a", "h", "i", "j", "b", "d", "e", "f", "c", "g"}
+	values := pq.DequeueN(len(expected))
+	for i := range expected {
+		if values[i] != expected[i] {
+			t.Errorf("DequeueN() error; expected: %v, got: %v", expected, values)
+			break
+		}
+	}
+	if pq.Size() != 0 {
+		t.Error("DequeueN() failed: MinPriorityQueue should be empty")
+	}
+}
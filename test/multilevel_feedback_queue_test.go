@@ -0,0 +1,81 @@
+package gost_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/christat/gost/queue"
+)
+
+var mlfqQuanta = []time.Duration{10 * time.Millisecond, 20 * time.Millisecond, 40 * time.Millisecond}
+
+// test helper function; dequeues a task and checks it against the expected task and level.
+func expectTask(t *testing.T, mlfq *gost.MultilevelFeedbackQueue, task interface{}, level int) {
+	t.Helper()
+	value, l, quantum := mlfq.Dequeue()
+	if value != task || l != level {
+		t.Fatalf("Dequeue() error; expected: %v at level %v, got: %v at level %v", task, level, value, l)
+	}
+	if level >= 0 && quantum != mlfqQuanta[level] {
+		t.Fatalf("Dequeue() error; expected quantum: %v, got: %v", mlfqQuanta[level], quantum)
+	}
+}
+
+func TestMultilevelFeedbackQueue_Demotion(t *testing.T) {
+	mlfq, err := gost.NewMultilevelFeedbackQueue(mlfqQuanta, 0, nil, nil)
+	if err != nil {
+		t.Fatalf("NewMultilevelFeedbackQueue() failed unexpectedly: %v", err)
+	}
+	if mlfq.Levels() != 3 {
+		t.Errorf("Levels() error; expected: %v, got: %v", 3, mlfq.Levels())
+	}
+	mlfq.Enqueue("cpu")
+	mlfq.Enqueue("io")
+	expectTask(t, mlfq, "cpu", 0)
+	mlfq.Requeue("cpu", 0, 10*time.Millisecond) // used up its quantum: demoted
+	expectTask(t, mlfq, "io", 0)
+	mlfq.Requeue("io", 0, time.Millisecond) // yielded early: keeps its level
+	expectTask(t, mlfq, "io", 0)
+	expectTask(t, mlfq, "cpu", 1)
+	mlfq.Requeue("cpu", 1, 20*time.Millisecond)
+	expectTask(t, mlfq, "cpu", 2)
+	mlfq.Requeue("cpu", 2, 40*time.Millisecond) // lowest level: stays there
+	if mlfq.LevelSize(2) != 1 || mlfq.Size() != 1 {
+		t.Errorf("Requeue() error; expected task to remain at the lowest level")
+	}
+	expectTask(t, mlfq, "cpu", 2)
+	expectTask(t, mlfq, nil, -1)
+}
+
+func TestMultilevelFeedbackQueue_Boost(t *testing.T) {
+	clock := newFakeClock()
+	mlfq, err := gost.NewMultilevelFeedbackQueue(mlfqQuanta, time.Second, func() gost.Queue { return gost.NewQueue(10) }, clock)
+	if err != nil {
+		t.Fatalf("NewMultilevelFeedbackQueue() failed unexpectedly: %v", err)
+	}
+	mlfq.Enqueue("a")
+	mlfq.Enqueue("b")
+	expectTask(t, mlfq, "a", 0)
+	mlfq.Requeue("a", 0, time.Second)
+	expectTask(t, mlfq, "b", 0)
+	mlfq.Requeue("b", 0, time.Second)
+	expectTask(t, mlfq, "a", 1)
+	mlfq.Requeue("a", 1, time.Second)
+	if mlfq.LevelSize(1) != 1 || mlfq.LevelSize(2) != 1 {
+		t.Fatal("Requeue() error; expected tasks at levels 1 and 2")
+	}
+	clock.Advance(time.Second)
+	mlfq.Enqueue("c")
+	// The boost happens before "c" is served, and keeps previously queued tasks ahead of boosted ones.
+	expectTask(t, mlfq, "c", 0)
+	expectTask(t, mlfq, "b", 0)
+	expectTask(t, mlfq, "a", 0)
+}
+
+func TestMultilevelFeedbackQueue_NoLevels(t *testing.T) {
+	for _, quanta := range [][]time.Duration{nil, {}} {
+		if mlfq, err := gost.NewMultilevelFeedbackQueue(quanta, 0, nil, nil); err == nil || mlfq != nil {
+			t.Errorf("NewMultilevelFeedbackQueue() did not return error without levels")
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package gost_test
 
 import (
+	"math/rand"
 	"testing"
 
 	"github.com/christat/gost/list"
@@ -94,3 +95,375 @@ func TestNodeList_Remove(t *testing.T) {
 		t.Errorf("Add() error; expected to retrieve middle element: %v, got: %v", newVector(num/2+1), value)
 	}
 }
+
+func TestNodeList_RemoveOnly(t *testing.T) {
+	list := new(gost.NodeList)
+	list.Append("a")
+	value, err := list.Remove(0)
+	if err != nil || value != "a" {
+		t.Fatalf("Remove() failed: returned: %v, expected: %v", value, "a")
+	}
+	if list.Head != nil || list.Tail != nil || list.Size() != 0 {
+		t.Fatalf("Remove() error; expected empty list, got Head: %v, Tail: %v, size: %v", list.Head, list.Tail, list.Size())
+	}
+	// The list remains usable once emptied.
+	list.Append("b")
+	if value, _ := list.Retrieve(0); value != "b" || list.Head != list.Tail {
+		t.Errorf("Append() error after emptying list; expected: %v, got: %v", "b", value)
+	}
+}
+
+func TestNodeList_Elements(t *testing.T) {
+	list := new(gost.NodeList)
+	if list.Front() != nil || list.Back() != nil {
+		t.Fatal("Front() and Back() did not return nil on empty list")
+	}
+	list.Append(0)
+	e := list.Front()
+	for i := 1; i < num; i++ {
+		var err error
+		if e, err = list.InsertAfter(e, i); err != nil {
+			t.Fatalf("InsertAfter() failed unexpectedly: %v", err)
+		}
+	}
+	if list.Size() != num || list.Back().Value() != num-1 {
+		t.Fatalf("InsertAfter() error; expected size: %v and last value: %v, got: %v and %v", num, num-1, list.Size(), list.Back().Value())
+	}
+	// Remove odd values while walking the list once.
+	for e := list.Front(); e != nil; e = list.Next(e) {
+		if next := list.Next(e); next != nil {
+			value, err := list.RemoveAfter(e)
+			if err != nil || value != e.Value().(int)+1 {
+				t.Fatalf("RemoveAfter() failed: returned: %v, expected: %v", value, e.Value().(int)+1)
+			}
+		}
+	}
+	if list.Size() != num/2 || list.Back().Value() != num-2 {
+		t.Fatalf("RemoveAfter() error; expected size: %v and last value: %v, got: %v and %v", num/2, num-2, list.Size(), list.Back().Value())
+	}
+	for i, e := 0, list.Front(); e != nil; i, e = i+2, list.Next(e) {
+		if e.Value() != i {
+			t.Fatalf("Next() error; expected value: %v, got: %v", i, e.Value())
+		}
+	}
+	if _, err := list.RemoveAfter(list.Back()); err == nil {
+		t.Error("RemoveAfter() did not return error on last element")
+	}
+}
+
+func TestNodeList_InvalidElements(t *testing.T) {
+	list, other := generateList(3), generateList(3)
+	if _, err := list.InsertAfter(other.Front(), 0); err == nil {
+		t.Error("InsertAfter() did not return error on element of another list")
+	}
+	second := list.Next(list.Front())
+	last := list.Back()
+	list.RemoveAfter(list.Front())
+	if _, err := list.InsertAfter(second, 0); err == nil {
+		t.Error("InsertAfter() did not return error on removed element")
+	}
+	list.RemoveAfter(list.Front())
+	if _, err := list.RemoveAfter(last); err == nil {
+		t.Error("RemoveAfter() did not return error on removed element")
+	}
+	if list.Size() != 1 || list.Back().Value() != list.Front().Value() {
+		t.Errorf("RemoveAfter() error; expected a single element, got size: %v", list.Size())
+	}
+	list.Remove(0)
+	if list.Front() != nil || list.Back() != nil || list.Head != nil || list.Tail != nil {
+		t.Errorf("Remove() error; expected empty list after removing its only element")
+	}
+}
+
+func TestNodeList_InvalidElementsAfterSplitAt(t *testing.T) {
+	list := generateIntList(4)
+	moved := list.Next(list.Next(list.Front())) // still linked to its successor once moved
+	rest, _ := list.SplitAt(2)
+	if _, err := list.InsertAfter(moved, 9); err == nil {
+		t.Error("InsertAfter() did not return error on element moved to another list")
+	}
+	if _, err := rest.RemoveAfter(moved); err == nil {
+		t.Error("RemoveAfter() did not return error on element of another list")
+	}
+	if list.Next(moved) != nil {
+		t.Error("Next() did not return nil on element moved to another list")
+	}
+	assertList(t, list, 0, 1)
+	assertList(t, rest, 2, 3)
+	if _, err := list.InsertAfter(list.Front(), 9); err != nil {
+		t.Errorf("InsertAfter() failed unexpectedly on a fresh handle: %v", err)
+	}
+	assertList(t, list, 0, 9, 1)
+}
+
+func TestNodeList_InvalidElementsAfterConcat(t *testing.T) {
+	list, other := generateIntList(2), generateIntList(2)
+	moved := other.Front()
+	list.Concat(other)
+	other.Append(7)
+	if _, err := other.InsertAfter(moved, 9); err == nil {
+		t.Error("InsertAfter() did not return error on element moved to another list")
+	}
+	if _, err := other.RemoveAfter(moved); err == nil {
+		t.Error("RemoveAfter() did not return error on element moved to another list")
+	}
+	assertList(t, list, 0, 1, 0, 1)
+	assertList(t, other, 7)
+	// Handles of the list gaining values stay valid.
+	kept := list.Front()
+	other.Append(8)
+	list.Splice(1, other)
+	if _, err := list.RemoveAfter(kept); err != nil {
+		t.Errorf("RemoveAfter() failed unexpectedly on a handle of the receiving list: %v", err)
+	}
+	assertList(t, list, 0, 8, 1, 0, 1)
+	if _, err := other.InsertAfter(moved, 9); err == nil {
+		t.Error("InsertAfter() did not return error on element moved to another list")
+	}
+}
+
+// test helper function; checks that list holds exactly the expected values, and that its tail is consistent.
+func assertList(t *testing.T, list *gost.NodeList, expected ...interface{}) {
+	t.Helper()
+	if list.Size() != len(expected) {
+		t.Fatalf("Size() error; expected: %v, got: %v", len(expected), list.Size())
+	}
+	for i, item := range expected {
+		if value, _ := list.Retrieve(i); value != item {
+			t.Fatalf("Retrieve() error; expected to get: %v at %v, got: %v", item, i, value)
+		}
+	}
+	if len(expected) > 0 && list.Back().Value() != expected[len(expected)-1] {
+		t.Fatalf("Back() error; expected: %v, got: %v", expected[len(expected)-1], list.Back().Value())
+	}
+}
+
+func TestNodeList_Cursor(t *testing.T) {
+	list := new(gost.NodeList)
+	for i := 0; i < 6; i++ {
+		list.Append(i)
+	}
+	// Delete multiples of 3, duplicate odd values and mark even ones, walking the list once.
+	for c := list.Cursor(); c.Next(); {
+		value := c.Value().(int)
+		switch {
+		case value%3 == 0:
+			c.Delete()
+		case value%2 == 1:
+			c.InsertAfter(value)
+			c.Next()
+		default:
+			c.InsertBefore("even")
+			c.Set(-value)
+		}
+	}
+	assertList(t, list, 1, 1, "even", -2, "even", -4, 5, 5)
+	c := list.Cursor()
+	if err := c.Set(0); err == nil {
+		t.Error("Set() did not return error before the first value")
+	}
+	if _, err := c.Delete(); err == nil {
+		t.Error("Delete() did not return error before the first value")
+	}
+}
+
+func TestNodeList_CursorEnds(t *testing.T) {
+	list := new(gost.NodeList)
+	c := list.Cursor()
+	c.InsertAfter(2)
+	c.InsertBefore(0)
+	if !c.Next() || c.Value() != 2 {
+		t.Fatalf("Next() error; expected value: %v, got: %v", 2, c.Value())
+	}
+	c.InsertBefore(1)
+	if c.Next() {
+		t.Fatalf("Next() error; expected end of list, got: %v", c.Value())
+	}
+	c.InsertBefore(3)
+	c.InsertAfter(4)
+	assertList(t, list, 0, 1, 2, 3, 4)
+	for c := list.Cursor(); c.Next(); {
+		c.Delete()
+	}
+	assertList(t, list)
+	if list.Head != nil || list.Tail != nil {
+		t.Errorf("Delete() error; expected empty list")
+	}
+	list.Append(5)
+	assertList(t, list, 5)
+}
+
+/*
+Cursor Benchmark: measure removing every other value in a single pass, compared to Remove(index)
+*/
+
+func BenchmarkNodeList_CursorDelete(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		list := generateList(num)
+		b.StartTimer()
+		for c := list.Cursor(); c.Next(); {
+			c.Delete()
+			c.Next()
+		}
+	}
+}
+
+func BenchmarkNodeList_IndexRemove(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		list := generateList(num)
+		b.StartTimer()
+		for index := 0; index < list.Size(); index++ {
+			list.Remove(index)
+		}
+	}
+}
+
+// test helper function; orders vectors by their x coordinate.
+func lessX(a, b interface{}) bool { return a.(*vector).x < b.(*vector).x }
+
+func TestNodeList_SortStable(t *testing.T) {
+	list := new(gost.NodeList)
+	for i := 0; i < num; i++ {
+		// Many values share their x coordinate; y keeps their insertion order.
+		list.Append(&vector{x: rand.Intn(num / 10), y: i})
+	}
+	list.SortStable(lessX)
+	if !list.IsSorted(lessX) || list.Size() != num {
+		t.Fatalf("SortStable() error; list not sorted or size changed: %v", list.Size())
+	}
+	var prev *vector
+	for c := list.Cursor(); c.Next(); prev = c.Value().(*vector) {
+		if current := c.Value().(*vector); prev != nil && prev.x == current.x && prev.y > current.y {
+			t.Fatalf("SortStable() error; equal values out of insertion order: %v before %v", prev, current)
+		}
+	}
+	last := list.Back().Value().(*vector)
+	list.Append(&vector{x: num})
+	if value, _ := list.Retrieve(num - 1); value != last {
+		t.Errorf("SortStable() error; tail not updated, expected: %v, got: %v", last, value)
+	}
+}
+
+func TestNodeList_Sort(t *testing.T) {
+	less := func(a, b interface{}) bool { return a.(int) < b.(int) }
+	for size := 0; size < 20; size++ {
+		list := new(gost.NodeList)
+		for _, value := range rand.Perm(size) {
+			list.Append(value)
+		}
+		list.Sort(less)
+		expected := make([]interface{}, size)
+		for i := range expected {
+			expected[i] = i
+		}
+		assertList(t, list, expected...)
+	}
+	list := generateIntList(5)
+	if !list.IsSorted(less) || list.IsSorted(func(a, b interface{}) bool { return a.(int) > b.(int) }) {
+		t.Error("IsSorted() error; unexpected result")
+	}
+}
+
+func TestNodeList_InsertSorted(t *testing.T) {
+	list := new(gost.NodeList)
+	for _, x := range []int{5, 1, 9, 5, 0, 9} {
+		list.InsertSorted(&vector{x: x, y: list.Size()}, lessX)
+	}
+	expected := []vector{{0, 4, 0}, {1, 1, 0}, {5, 0, 0}, {5, 3, 0}, {9, 2, 0}, {9, 5, 0}}
+	for i, e := 0, list.Front(); e != nil; i, e = i+1, list.Next(e) {
+		if *(e.Value().(*vector)) != expected[i] {
+			t.Fatalf("InsertSorted() error; expected: %v at %v, got: %v", expected[i], i, e.Value())
+		}
+	}
+	if *(list.Back().Value().(*vector)) != expected[len(expected)-1] {
+		t.Errorf("InsertSorted() error; tail not updated, got: %v", list.Back().Value())
+	}
+}
+
+/*
+Sort Benchmark: measure sorting a shuffled list in place
+*/
+
+func BenchmarkNodeList_Sort(b *testing.B) {
+	values := rand.Perm(bigNum)
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		list := new(gost.NodeList)
+		for _, value := range values {
+			list.Append(value)
+		}
+		b.StartTimer()
+		list.Sort(func(a, b interface{}) bool { return a.(int) < b.(int) })
+	}
+}
+
+func TestNodeList_Concat(t *testing.T) {
+	list, other := generateIntList(3), generateIntList(2)
+	if err := list.Concat(list); err == nil {
+		t.Error("Concat() did not return error on the list itself")
+	}
+	list.Concat(other)
+	assertList(t, list, 0, 1, 2, 0, 1)
+	assertList(t, other)
+	list.Concat(other)
+	other.Concat(list)
+	assertList(t, other, 0, 1, 2, 0, 1)
+	assertList(t, list)
+	other.Append(5)
+	assertList(t, other, 0, 1, 2, 0, 1, 5)
+}
+
+func TestNodeList_Splice(t *testing.T) {
+	list := generateIntList(4)
+	if err := list.Splice(5, generateIntList(1)); err == nil {
+		t.Error("Splice() did not return error on exceeding size index")
+	}
+	list.Splice(2, generateIntList(2))
+	assertList(t, list, 0, 1, 0, 1, 2, 3)
+	list.Splice(0, generateIntList(1))
+	list.Splice(-1, generateIntList(1))
+	list.Splice(list.Size(), generateIntList(1))
+	assertList(t, list, 0, 0, 1, 0, 1, 2, 0, 3, 0)
+}
+
+func TestNodeList_SplitAt(t *testing.T) {
+	list := generateIntList(6)
+	if _, err := list.SplitAt(7); err == nil {
+		t.Error("SplitAt() did not return error on exceeding size index")
+	}
+	rest, _ := list.SplitAt(4)
+	assertList(t, list, 0, 1, 2, 3)
+	assertList(t, rest, 4, 5)
+	empty, _ := rest.SplitAt(2)
+	assertList(t, empty)
+	all, _ := list.SplitAt(0)
+	assertList(t, list)
+	assertList(t, all, 0, 1, 2, 3)
+	list.Append(9)
+	all.Append(4)
+	assertList(t, list, 9)
+	assertList(t, all, 0, 1, 2, 3, 4)
+}
+
+func TestNodeList_ReverseRotate(t *testing.T) {
+	list := generateIntList(5)
+	list.Reverse()
+	assertList(t, list, 4, 3, 2, 1, 0)
+	list.Reverse()
+	list.Rotate(2)
+	assertList(t, list, 2, 3, 4, 0, 1)
+	list.Rotate(-2)
+	assertList(t, list, 0, 1, 2, 3, 4)
+	list.Rotate(11)
+	assertList(t, list, 1, 2, 3, 4, 0)
+	list.Rotate(5)
+	assertList(t, list, 1, 2, 3, 4, 0)
+	list.Append(5)
+	assertList(t, list, 1, 2, 3, 4, 0, 5)
+	single := generateIntList(1)
+	single.Reverse()
+	single.Rotate(3)
+	assertList(t, single, 0)
+}
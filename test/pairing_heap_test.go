@@ -0,0 +1,228 @@
+package gost_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/christat/gost/queue"
+)
+
+// mergeableHeap is the method set shared by PairingHeap and LeftistHeap, used to test both with the same helpers.
+type mergeableHeap interface {
+	Enqueue(item interface{}, priority float64) *gost.Handle
+	Dequeue() interface{}
+	Peek() interface{}
+	UpdatePriority(handle *gost.Handle, priority float64) error
+	Size() int
+}
+
+// test helper function; checks the basic Enqueue/Dequeue contract shared with PriorityQueue.
+func testMergeableHeapDequeue(t *testing.T, heap mergeableHeap) {
+	heap.Enqueue("a", 0)
+	heap.Enqueue("b", 5)
+	heap.Enqueue("c", 10)
+	heap.Enqueue("d", 5)
+	if value := heap.Peek(); value != "c" {
+		t.Errorf("Peek() failed: returned: %v, expected: %v", value, "c")
+	}
+	for _, expected := range []string{"c", "b", "d", "a"} {
+		if value := heap.Dequeue(); value != expected {
+			t.Errorf("Dequeue() failed: returned: %v, expected: %v", value, expected)
+		}
+	}
+	if heap.Size() != 0 {
+		t.Error("Dequeue() failed: heap should be empty")
+	}
+	if heap.Dequeue() != nil || heap.Peek() != nil {
+		t.Error("Dequeue() failed: heap returned non-nil value when empty")
+	}
+}
+
+// test helper function; runs random operations against a brute-force model, updating priorities through handles.
+func testMergeableHeapRandom(t *testing.T, heap mergeableHeap) {
+	type entry struct {
+		handle   *gost.Handle
+		priority float64
+		counter  int
+	}
+	var model []entry
+	random := rand.New(rand.NewSource(42))
+	for i := 0; i < num; i++ {
+		switch operation := random.Intn(4); {
+		case operation < 2 || len(model) == 0:
+			priority := float64(random.Intn(50))
+			model = append(model, entry{handle: heap.Enqueue(i, priority), priority: priority, counter: i})
+		case operation == 2:
+			index := random.Intn(len(model))
+			model[index].priority = float64(random.Intn(50))
+			if err := heap.UpdatePriority(model[index].handle, model[index].priority); err != nil {
+				t.Fatalf("UpdatePriority() failed unexpectedly: %v", err)
+			}
+		default:
+			best := 0
+			for j, e := range model {
+				if e.priority > model[best].priority || (e.priority == model[best].priority && e.counter < model[best].counter) {
+					best = j
+				}
+			}
+			if value := heap.Dequeue(); value != model[best].handle.Value() {
+				t.Fatalf("Dequeue() failed: returned: %v, expected: %v", value, model[best].handle.Value())
+			}
+			if err := heap.UpdatePriority(model[best].handle, 0); err == nil {
+				t.Fatal("UpdatePriority() did not return error on dequeued handle")
+			}
+			model = append(model[:best], model[best+1:]...)
+		}
+		if heap.Size() != len(model) {
+			t.Fatalf("Size() failed: returned: %v, expected: %v", heap.Size(), len(model))
+		}
+	}
+}
+
+// test helper function; checks that priorities updated through handles reorder the heap.
+func testMergeableHeapUpdatePriority(t *testing.T, heap mergeableHeap) {
+	low := heap.Enqueue("low", 1)
+	heap.Enqueue("middle", 5)
+	high := heap.Enqueue("high", 10)
+	heap.UpdatePriority(low, 20)
+	heap.UpdatePriority(high, 0)
+	for _, expected := range []string{"low", "middle", "high"} {
+		if value := heap.Dequeue(); value != expected {
+			t.Errorf("UpdatePriority() failed: dequeued: %v, expected: %v", value, expected)
+		}
+	}
+}
+
+// test helper function; fills a and b, then checks that meld (which melds b into a) keeps order and handles valid.
+func testMergeableHeapMeld(t *testing.T, a, b mergeableHeap, meld func()) {
+	for i := 0; i < num; i++ {
+		a.Enqueue(i, float64(2*i))
+		b.Enqueue(i, float64(2*i+1))
+	}
+	handle := b.Enqueue("first", -1)
+	meld()
+	if a.Size() != 2*num+1 || b.Size() != 0 {
+		t.Errorf("Meld() failed: sizes: %v and %v, expected: %v and %v", a.Size(), b.Size(), 2*num+1, 0)
+	}
+	a.UpdatePriority(handle, 2*num)
+	if value := a.Dequeue(); value != "first" {
+		t.Errorf("Meld() failed: melded handle did not remain valid, dequeued: %v", value)
+	}
+	for i := num - 1; i >= 0; i-- {
+		for j := 0; j < 2; j++ {
+			if value := a.Dequeue(); value != i {
+				t.Fatalf("Meld() failed: dequeued: %v, expected: %v", value, i)
+			}
+		}
+	}
+}
+
+func TestPairingHeap_Dequeue(t *testing.T) {
+	testMergeableHeapDequeue(t, gost.NewPairingHeap())
+}
+
+func TestPairingHeap_UpdatePriority(t *testing.T) {
+	testMergeableHeapUpdatePriority(t, gost.NewPairingHeap())
+}
+
+func TestPairingHeap_Random(t *testing.T) {
+	testMergeableHeapRandom(t, gost.NewPairingHeap())
+}
+
+func TestPairingHeap_Meld(t *testing.T) {
+	a, b := gost.NewPairingHeap(), gost.NewPairingHeap()
+	testMergeableHeapMeld(t, a, b, func() { a.Meld(b) })
+}
+
+/*
+Mergeable heap Benchmark:
+The following methods are meant to put the mergeable heaps to the test against the container/heap based PriorityQueue.
+
+	- Basic tests fill and subsequently empty the structure with N randomly prioritized vector elements.
+	- Meld tests combine two structures of N elements; PriorityQueue has to drain one into the other.
+*/
+
+// benchmark helper function returning a fixed sequence of random priorities.
+func benchmarkPriorities(size int) []float64 {
+	random := rand.New(rand.NewSource(42))
+	priorities := make([]float64, size)
+	for i := range priorities {
+		priorities[i] = random.Float64()
+	}
+	return priorities
+}
+
+func benchmarkPriorityQueueBasicTest(size int, b *testing.B) {
+	priorities := benchmarkPriorities(size)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		pq := gost.NewPriorityQueue()
+		for j, priority := range priorities {
+			pq.Enqueue(newVector(j), priority)
+		}
+		for pq.Size() > 0 {
+			pq.Dequeue()
+		}
+	}
+}
+
+func benchmarkPairingHeapBasicTest(size int, b *testing.B) {
+	priorities := benchmarkPriorities(size)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		heap := gost.NewPairingHeap()
+		for j, priority := range priorities {
+			heap.Enqueue(newVector(j), priority)
+		}
+		for heap.Size() > 0 {
+			heap.Dequeue()
+		}
+	}
+}
+
+func BenchmarkPriorityQueue_BasicTest1000(b *testing.B) {
+	benchmarkPriorityQueueBasicTest(1000, b)
+}
+
+func BenchmarkPriorityQueue_BasicTest100000(b *testing.B) {
+	benchmarkPriorityQueueBasicTest(100000, b)
+}
+
+func BenchmarkPairingHeap_BasicTest1000(b *testing.B) {
+	benchmarkPairingHeapBasicTest(1000, b)
+}
+
+func BenchmarkPairingHeap_BasicTest100000(b *testing.B) {
+	benchmarkPairingHeapBasicTest(100000, b)
+}
+
+func BenchmarkPriorityQueue_Meld(b *testing.B) {
+	priorities := benchmarkPriorities(num)
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		first, second := gost.NewPriorityQueue(), gost.NewPriorityQueue()
+		for j, priority := range priorities {
+			first.Enqueue(newVector(j), priority)
+			second.Enqueue(newVector(j), priority)
+		}
+		b.StartTimer()
+		// Dequeue() does not expose priorities, so drained items are re-enqueued with a constant one.
+		for second.Size() > 0 {
+			first.Enqueue(second.Dequeue(), 0)
+		}
+	}
+}
+
+func BenchmarkPairingHeap_Meld(b *testing.B) {
+	priorities := benchmarkPriorities(num)
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		first, second := gost.NewPairingHeap(), gost.NewPairingHeap()
+		for j, priority := range priorities {
+			first.Enqueue(newVector(j), priority)
+			second.Enqueue(newVector(j), priority)
+		}
+		b.StartTimer()
+		first.Meld(second)
+	}
+}
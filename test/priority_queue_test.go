@@ -73,3 +73,60 @@ func TestPriorityQueue_Pop(t *testing.T) {
 		t.Error("Dequeue() failed: PriorityQueue returned non-nil value when empty")
 	}
 }
+
+func TestPriorityQueue_EnqueueAll(t *testing.T) {
+	if _, err := gost.NewPriorityQueueFrom([]interface{}{"a"}, nil); err == nil {
+		t.Error("NewPriorityQueueFrom() did not return error on mismatched lengths")
+	}
+	pq, err := gost.NewPriorityQueueFrom([]interface{}{"a", "b", "c", "d"}, []float64{0, 5, 10, 5})
+	if err != nil {
+		t.Fatalf("NewPriorityQueueFrom() failed unexpectedly: %v", err)
+	}
+	// Few items into a larger heap are pushed one by one; FIFO order must hold across both paths.
+	pq.EnqueueAll([]interface{}{"e"}, []float64{5})
+	pq.EnqueueAll([]interface{}{"f", "g", "h", "i", "j"}, []float64{5, 10, 0, 1, 1})
+	expected := []interface{}{"c", "g", "b", "d", "e", "f", "i", "j", "a", "h"}
+	values := pq.DequeueN(len(expected) + 1)
+	if len(values) != len(expected) {
+		t.Fatalf("DequeueN() error; expected %v items, got: %v", len(expected), len(values))
+	}
+	for i := range expected {
+		if values[i] != expected[i] {
+			t.Errorf("DequeueN() error; expected: %v, got: %v", expected, values)
+			break
+		}
+	}
+	if pq.Size() != 0 || len(pq.DequeueN(1)) != 0 {
+		t.Error("DequeueN() failed: PriorityQueue should be empty")
+	}
+}
+
+// benchmark helper function returning size vectors along with their priorities.
+func bulkItems(size int) ([]interface{}, []float64) {
+	items := make([]interface{}, size)
+	priorities := make([]float64, size)
+	for i := range items {
+		items[i] = newVector(i)
+		priorities[i] = float64((i * 7919) % size)
+	}
+	return items, priorities
+}
+
+func BenchmarkPriorityQueue_Enqueue(b *testing.B) {
+	items, priorities := bulkItems(bigNum)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		pq := gost.NewPriorityQueue()
+		for j, item := range items {
+			pq.Enqueue(item, priorities[j])
+		}
+	}
+}
+
+func BenchmarkPriorityQueue_EnqueueAll(b *testing.B) {
+	items, priorities := bulkItems(bigNum)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		gost.NewPriorityQueueFrom(items, priorities)
+	}
+}
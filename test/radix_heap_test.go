@@ -0,0 +1,141 @@
+package gost_test
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/christat/gost/queue"
+)
+
+func TestRadixHeap_Dequeue(t *testing.T) {
+	heap := gost.NewRadixHeap()
+	heap.Enqueue("a", 10)
+	heap.Enqueue("b", 3)
+	heap.Enqueue("c", 7)
+	heap.Enqueue("d", 3)
+	for _, expected := range []string{"b", "d", "c", "a"} {
+		if value := heap.Dequeue(); value != expected {
+			t.Errorf("Dequeue() failed: returned: %v, expected: %v", value, expected)
+		}
+	}
+	if heap.Last() != 10 {
+		t.Errorf("Last() failed: returned: %v, expected: %v", heap.Last(), 10)
+	}
+	if heap.Size() != 0 || heap.Dequeue() != nil {
+		t.Error("Dequeue() failed: RadixHeap should be empty")
+	}
+}
+
+func TestRadixHeap_Monotone(t *testing.T) {
+	heap := gost.NewRadixHeap()
+	heap.Enqueue("a", 5)
+	heap.Dequeue()
+	if err := heap.Enqueue("b", 4); err == nil {
+		t.Error("Enqueue() did not return error on priority lower than the last dequeued one")
+	}
+	if err := heap.Enqueue("c", 5); err != nil {
+		t.Errorf("Enqueue() failed on priority equal to the last dequeued one: %v", err)
+	}
+}
+
+func TestRadixHeap_FIFO(t *testing.T) {
+	heap := gost.NewRadixHeap()
+	heap.Enqueue("first", 12)
+	heap.Enqueue("low", 8)
+	heap.Dequeue()
+	// "second" lands directly in the bucket "first" is later moved into, ahead of it.
+	heap.Enqueue("second", 12)
+	for _, expected := range []string{"first", "second"} {
+		if value := heap.Dequeue(); value != expected {
+			t.Errorf("Dequeue() failed: returned: %v, expected: %v", value, expected)
+		}
+	}
+}
+
+func TestRadixHeap_Random(t *testing.T) {
+	type entry struct {
+		value    int
+		priority uint64
+	}
+	var model []entry
+	heap := gost.NewRadixHeap()
+	random := rand.New(rand.NewSource(42))
+	for i := 0; i < num; i++ {
+		if random.Intn(2) == 0 || len(model) == 0 {
+			priority := heap.Last() + uint64(random.Intn(1000))
+			heap.Enqueue(i, priority)
+			model = append(model, entry{value: i, priority: priority})
+			continue
+		}
+		sort.SliceStable(model, func(a, b int) bool { return model[a].priority < model[b].priority })
+		if value := heap.Dequeue(); value != model[0].value {
+			t.Fatalf("Dequeue() failed: returned: %v, expected: %v", value, model[0].value)
+		}
+		if heap.Last() != model[0].priority {
+			t.Fatalf("Last() failed: returned: %v, expected: %v", heap.Last(), model[0].priority)
+		}
+		model = model[1:]
+	}
+}
+
+/*
+RadixHeap Benchmark:
+The following methods are meant to put this implementation to the test against MinPriorityQueue on a monotone
+workload, where each dequeued item enqueues a couple of items with slightly higher priorities (as in Dijkstra's).
+Items carry their own priority, so that both structures go through the exact same sequence of operations.
+*/
+
+// benchmark helper function returning a fixed sequence of random priority increments.
+func benchmarkIncrements(size int) []uint64 {
+	random := rand.New(rand.NewSource(42))
+	increments := make([]uint64, size)
+	for i := range increments {
+		increments[i] = uint64(random.Intn(1000))
+	}
+	return increments
+}
+
+func benchmarkRadixHeapMonotone(size int, b *testing.B) {
+	increments := benchmarkIncrements(2 * size)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		heap := gost.NewRadixHeap()
+		heap.Enqueue(uint64(0), 0)
+		for j := 0; j < size; j++ {
+			last := heap.Dequeue().(uint64)
+			heap.Enqueue(last+increments[2*j], last+increments[2*j])
+			heap.Enqueue(last+increments[2*j+1], last+increments[2*j+1])
+		}
+	}
+}
+
+func benchmarkMinPriorityQueueMonotone(size int, b *testing.B) {
+	increments := benchmarkIncrements(2 * size)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		pq := gost.NewMinPriorityQueue()
+		pq.Enqueue(uint64(0), 0)
+		for j := 0; j < size; j++ {
+			last := pq.Dequeue().(uint64)
+			pq.Enqueue(last+increments[2*j], float64(last+increments[2*j]))
+			pq.Enqueue(last+increments[2*j+1], float64(last+increments[2*j+1]))
+		}
+	}
+}
+
+func BenchmarkRadixHeap_Monotone1000(b *testing.B) {
+	benchmarkRadixHeapMonotone(1000, b)
+}
+
+func BenchmarkRadixHeap_Monotone100000(b *testing.B) {
+	benchmarkRadixHeapMonotone(100000, b)
+}
+
+func BenchmarkMinPriorityQueue_Monotone1000(b *testing.B) {
+	benchmarkMinPriorityQueueMonotone(1000, b)
+}
+
+func BenchmarkMinPriorityQueue_Monotone100000(b *testing.B) {
+	benchmarkMinPriorityQueueMonotone(100000, b)
+}
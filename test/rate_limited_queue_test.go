@@ -0,0 +1,234 @@
+package gost_test
+
+import (
+	"context"
+	"math"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/christat/gost/queue"
+)
+
+// test helper function; enqueues size vectors into a RateLimitedQueue over a NodeQueue.
+func generateRateLimitedQueue(size int, rate float64, burst int, clock *fakeClock) *gost.RateLimitedQueue {
+	queue := gost.NewRateLimitedQueue(new(gost.NodeQueue), rate, burst, clock)
+	for i := 0; i < size; i++ {
+		queue.Enqueue(newVector(i))
+	}
+	return queue
+}
+
+func TestRateLimitedQueue_Dequeue(t *testing.T) {
+	clock := newFakeClock()
+	queue := generateRateLimitedQueue(10, 2, 3, clock)
+	// The bucket starts full: a burst of 3 items is released right away.
+	for i := 0; i < 3; i++ {
+		value := queue.Dequeue()
+		if value == nil || *(value.(*vector)) != *newVector(i) {
+			t.Fatalf("Dequeue() error; expected: %v, got: %v", newVector(i), value)
+		}
+	}
+	if value := queue.Dequeue(); value != nil {
+		t.Fatalf("Dequeue() error; released %v beyond the burst size", value)
+	}
+	clock.Advance(500 * time.Millisecond)
+	if value := queue.Dequeue(); value == nil || *(value.(*vector)) != *newVector(3) {
+		t.Fatalf("Dequeue() error; expected: %v after refill, got: %v", newVector(3), value)
+	}
+	if value := queue.Dequeue(); value != nil {
+		t.Fatalf("Dequeue() error; released %v beyond the rate", value)
+	}
+	// Idle time only accrues up to the burst size.
+	clock.Advance(time.Hour)
+	released := 0
+	for queue.Dequeue() != nil {
+		released++
+	}
+	if released != 3 || queue.Size() != 3 {
+		t.Errorf("Dequeue() error; expected to release %v items leaving %v, released %v leaving %v", 3, 3, released, queue.Size())
+	}
+	empty := gost.NewRateLimitedQueue(new(gost.NodeQueue), 1, 1, clock)
+	if empty.Dequeue() != nil {
+		t.Error("Dequeue() did not return nil on empty queue")
+	}
+}
+
+func TestRateLimitedQueue_SetRate(t *testing.T) {
+	clock := newFakeClock()
+	queue := generateRateLimitedQueue(10, 1, 1, clock)
+	queue.Dequeue()
+	queue.SetRate(10, 5)
+	clock.Advance(200 * time.Millisecond)
+	released := 0
+	for queue.Dequeue() != nil {
+		released++
+	}
+	if released != 2 {
+		t.Errorf("SetRate() error; expected to release %v items, released %v", 2, released)
+	}
+}
+
+func TestRateLimitedQueue_KeyLimit(t *testing.T) {
+	clock := newFakeClock()
+	queue := gost.NewRateLimitedQueue(gost.NewQueue(10), 100, 100, clock)
+	queue.SetKeyLimit(func(item interface{}) interface{} { return item.(string)[0] }, 1, 1)
+	for _, item := range []string{"a1", "a2", "a3", "b1", "b2", "c1"} {
+		queue.Enqueue(item)
+	}
+	// Items of other keys flow past the ones out of budget for theirs, which keep their FIFO order.
+	for _, expected := range []interface{}{"a1", "b1", "c1", nil} {
+		if value := queue.Dequeue(); value != expected {
+			t.Fatalf("Dequeue() error; expected: %v, got: %v", expected, value)
+		}
+	}
+	if queue.Size() != 3 {
+		t.Fatalf("Dequeue() error; expected %v items held back, got: %v", 3, queue.Size())
+	}
+	clock.Advance(time.Second)
+	for _, expected := range []interface{}{"a2", "b2", nil} {
+		if value := queue.Dequeue(); value != expected {
+			t.Fatalf("Dequeue() error; expected: %v, got: %v", expected, value)
+		}
+	}
+	// Removing per-key limits releases held items in their original order.
+	queue.Enqueue("b3")
+	queue.SetKeyLimit(nil, 0, 0)
+	for _, expected := range []interface{}{"a3", "b3"} {
+		if value := queue.Dequeue(); value != expected {
+			t.Fatalf("Dequeue() error; expected: %v, got: %v", expected, value)
+		}
+	}
+}
+
+func TestRateLimitedQueue_Take(t *testing.T) {
+	clock := newFakeClock()
+	queue := generateRateLimitedQueue(2, 1, 1, clock)
+	if _, err := queue.Take(context.Background()); err != nil {
+		t.Fatalf("Take() failed unexpectedly: %v", err)
+	}
+	result := make(chan interface{})
+	go func() {
+		value, _ := queue.Take(context.Background())
+		result <- value
+	}()
+	clock.WaitForWaiters(1)
+	clock.Advance(time.Second)
+	if value := <-result; value == nil || *(value.(*vector)) != *newVector(1) {
+		t.Fatalf("Take() error; expected: %v, got: %v", newVector(1), value)
+	}
+	// Taking from an empty queue waits for an item to be enqueued.
+	clock.Advance(time.Second)
+	go func() {
+		value, _ := queue.Take(context.Background())
+		result <- value
+	}()
+	queue.Enqueue("late")
+	if value := <-result; value != "late" {
+		t.Fatalf("Take() error; expected: %v, got: %v", "late", value)
+	}
+}
+
+func TestRateLimitedQueue_TakeSetRate(t *testing.T) {
+	clock := newFakeClock()
+	queue := generateRateLimitedQueue(1, 0, 0, clock)
+	result := make(chan interface{})
+	go func() {
+		value, _ := queue.Take(context.Background())
+		result <- value
+	}()
+	// A zero rate never refills; raising it from another goroutine wakes the blocked Take() up, which then
+	// waits for the new rate to provide a token.
+	queue.SetRate(1, 1)
+	clock.WaitForWaiters(1)
+	clock.Advance(time.Second)
+	if value := <-result; value == nil || *(value.(*vector)) != *newVector(0) {
+		t.Fatalf("Take() error; expected: %v, got: %v", newVector(0), value)
+	}
+}
+
+func TestRateLimitedQueue_TakeCancel(t *testing.T) {
+	clock := newFakeClock()
+	queue := generateRateLimitedQueue(1, 0, 0, clock)
+	ctx, cancel := context.WithCancel(context.Background())
+	result := make(chan error)
+	go func() {
+		_, err := queue.Take(ctx)
+		result <- err
+	}()
+	cancel()
+	if err := <-result; err != context.Canceled {
+		t.Errorf("Take() error; expected: %v, got: %v", context.Canceled, err)
+	}
+	if queue.Size() != 1 {
+		t.Errorf("Take() error; cancelled call should not release items, size: %v", queue.Size())
+	}
+}
+
+// countingClock counts the timers requested from a fakeClock.
+type countingClock struct {
+	*fakeClock
+	timers int32
+}
+
+func (c *countingClock) After(d time.Duration) <-chan time.Time {
+	atomic.AddInt32(&c.timers, 1)
+	return c.fakeClock.After(d)
+}
+
+func TestRateLimitedQueue_TinyRate(t *testing.T) {
+	clock := &countingClock{fakeClock: newFakeClock()}
+	queue := gost.NewRateLimitedQueue(new(gost.NodeQueue), 1e-12, 1, clock)
+	queue.Enqueue(0)
+	queue.Enqueue(1)
+	queue.Dequeue()
+	// The wait for the next token exceeds time.Duration; Take() must block rather than spin on short timers.
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := queue.Take(ctx); err != context.DeadlineExceeded {
+		t.Fatalf("Take() error; expected: %v, got: %v", context.DeadlineExceeded, err)
+	}
+	if timers := atomic.LoadInt32(&clock.timers); timers > 1 {
+		t.Errorf("Take() error; expected to wait without timers, requested %v", timers)
+	}
+}
+
+func TestRateLimitedQueue_Concurrent(t *testing.T) {
+	queue := gost.NewRateLimitedQueue(new(gost.NodeQueue), math.Inf(1), num, nil)
+	queue.SetKeyLimit(func(item interface{}) interface{} { return item.(int) % 7 }, math.Inf(1), num)
+	var wait sync.WaitGroup
+	taken := make(chan interface{}, num)
+	for worker := 0; worker < 4; worker++ {
+		wait.Add(2)
+		go func(worker int) {
+			defer wait.Done()
+			for i := worker; i < num; i += 4 {
+				queue.Enqueue(i)
+			}
+		}(worker)
+		go func() {
+			defer wait.Done()
+			for i := 0; i < num/4; i++ {
+				value, err := queue.Take(context.Background())
+				if err != nil {
+					t.Errorf("Take() failed unexpectedly: %v", err)
+					return
+				}
+				taken <- value
+			}
+		}()
+	}
+	wait.Wait()
+	close(taken)
+	seen := make(map[interface{}]bool)
+	for value := range taken {
+		if seen[value] {
+			t.Fatalf("Take() error; released %v twice", value)
+		}
+		seen[value] = true
+	}
+	if len(seen) != num || queue.Size() != 0 {
+		t.Errorf("Take() error; expected %v items released, got: %v", num, len(seen))
+	}
+}
@@ -0,0 +1,161 @@
+package gost_test
+
+import (
+	"math/rand"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/christat/gost/list"
+)
+
+// test helper function; orders int keys.
+func compareInts(a, b interface{}) int { return a.(int) - b.(int) }
+
+// test helper function; orders int keys of a SkipList.
+func compareIntKeys(a, b int) int { return a - b }
+
+// test helper function; returns a SkipList mapping every key to its double, along with the sorted keys.
+func generateSkipList(size int, seed int64) (*gost.SkipList[int, int], []int) {
+	list := gost.NewSkipList[int, int](compareIntKeys, seed)
+	random := rand.New(rand.NewSource(seed))
+	keys := make([]int, 0, size)
+	for len(keys) < size {
+		key := 2 * random.Intn(10*size) // even keys, so odd ones can be searched for
+		if !list.Contains(key) {
+			keys = append(keys, key)
+		}
+		list.Put(key, 2*key)
+	}
+	sort.Ints(keys)
+	return list, keys
+}
+
+func TestSkipList_PutGetDelete(t *testing.T) {
+	list, keys := generateSkipList(num, 1)
+	if list.Size() != num {
+		t.Fatalf("Put() error; expected size: %v, got: %v", num, list.Size())
+	}
+	for _, key := range keys {
+		if value, err := list.Get(key); err != nil || value != 2*key {
+			t.Fatalf("Get() failed: returned: %v, expected: %v", value, 2*key)
+		}
+	}
+	if _, err := list.Get(1); err == nil {
+		t.Error("Get() did not return error on missing key")
+	}
+	list.Put(keys[0], -1)
+	if value, _ := list.Get(keys[0]); value != -1 || list.Size() != num {
+		t.Fatalf("Put() error; expected value to be replaced, got: %v", value)
+	}
+	for i, key := range keys {
+		if i%2 == 0 {
+			if _, err := list.Delete(key); err != nil {
+				t.Fatalf("Delete() failed unexpectedly: %v", err)
+			}
+		}
+	}
+	if _, err := list.Delete(keys[0]); err == nil {
+		t.Error("Delete() did not return error on missing key")
+	}
+	if list.Size() != num/2 || list.Contains(keys[0]) || !list.Contains(keys[1]) {
+		t.Errorf("Delete() error; expected size: %v, got: %v", num/2, list.Size())
+	}
+}
+
+func TestSkipList_OrderQueries(t *testing.T) {
+	list, keys := generateSkipList(num, 2)
+	random := rand.New(rand.NewSource(2))
+	for i := 0; i < num; i++ {
+		key := random.Intn(20*num+2) - 1
+		index := sort.SearchInts(keys, key) // position of the lowest key greater or equal to key
+		if rank := list.Rank(key); rank != index {
+			t.Fatalf("Rank() error; expected: %v for key %v, got: %v", index, key, rank)
+		}
+		ceiling, _, err := list.Ceiling(key)
+		if index == len(keys) && err == nil || index < len(keys) && ceiling != keys[index] {
+			t.Fatalf("Ceiling() error; expected key %v to be found, got: %v (%v)", key, ceiling, err)
+		}
+		floorIndex := index - 1
+		if index < len(keys) && keys[index] == key {
+			floorIndex = index
+		}
+		floor, value, err := list.Floor(key)
+		if floorIndex < 0 && err == nil || floorIndex >= 0 && (floor != keys[floorIndex] || value != 2*keys[floorIndex]) {
+			t.Fatalf("Floor() error; expected key %v to be found, got: %v (%v)", key, floor, err)
+		}
+	}
+	// Ranks stay consistent as keys are deleted.
+	for _, key := range keys[:num/2] {
+		list.Delete(key)
+	}
+	for i, key := range keys[num/2:] {
+		if rank := list.Rank(key); rank != i {
+			t.Fatalf("Rank() error after Delete(); expected: %v, got: %v", i, rank)
+		}
+	}
+}
+
+func TestSkipList_Range(t *testing.T) {
+	list, keys := generateSkipList(100, 3)
+	from, to := keys[10], keys[20]+1
+	it := list.Range(from, to)
+	for i := 10; i <= 20; i++ {
+		if !it.Next() || it.Key() != keys[i] || it.Value() != 2*keys[i] {
+			t.Fatalf("Range() error; expected key: %v, got: %v", keys[i], it.Key())
+		}
+	}
+	if it.Next() {
+		t.Fatalf("Range() error; expected end of range, got: %v", it.Key())
+	}
+	count := 0
+	for it := list.Iterator(); it.Next(); count++ {
+		if it.Key() != keys[count] {
+			t.Fatalf("Iterator() error; expected key: %v, got: %v", keys[count], it.Key())
+		}
+	}
+	if count != len(keys) {
+		t.Errorf("Iterator() error; expected %v entries, got: %v", len(keys), count)
+	}
+	if below := gost.Collect(list.RangeTo(keys[3]).Values()); below.Size() != 3 {
+		t.Errorf("RangeTo() error; expected %v entries, got: %v", 3, below.Size())
+	}
+	if above := gost.Collect(list.RangeFrom(keys[96] + 1).Values()); above.Size() != 3 {
+		t.Errorf("RangeFrom() error; expected %v entries, got: %v", 3, above.Size())
+	}
+	if it := list.Range(keys[5], keys[5]); it.Next() {
+		t.Errorf("Range() error; expected empty range, got: %v", it.Key())
+	}
+}
+
+func TestSkipList_StringKeys(t *testing.T) {
+	list := gost.NewSkipList[string, int](strings.Compare, 1)
+	for i, key := range []string{"c", "a", "b"} {
+		list.Put(key, i)
+	}
+	if key, value, err := list.Floor("bb"); err != nil || key != "b" || value != 2 {
+		t.Errorf("Floor() failed: returned: %v, expected: %v", key, "b")
+	}
+	if _, _, err := list.Ceiling("d"); err == nil {
+		t.Error("Ceiling() did not return error past the greatest key")
+	}
+}
+
+/*
+SkipList Benchmark: measure insertions and lookups of random keys
+*/
+
+func BenchmarkSkipList_Put(b *testing.B) {
+	list := gost.NewSkipList[int, int](compareIntKeys, 1)
+	for i := 0; i < b.N; i++ {
+		list.Put(rand.Intn(bigNum), i)
+	}
+}
+
+func BenchmarkSkipList_Get(b *testing.B) {
+	list, _ := generateSkipList(bigNum/10, 1)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		list.Get(rand.Intn(2 * bigNum))
+	}
+}
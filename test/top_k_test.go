@@ -0,0 +1,125 @@
+package gost_test
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/christat/gost/queue"
+)
+
+// test helper function; compares the contents of a TopK with the expected values, best first.
+func assertSorted(t *testing.T, tk *gost.TopK, expected []interface{}) {
+	t.Helper()
+	sorted := tk.Sorted()
+	if len(sorted) != len(expected) {
+		t.Fatalf("Sorted() error; expected: %v, got: %v", expected, sorted)
+	}
+	for i := range expected {
+		if sorted[i] != expected[i] {
+			t.Fatalf("Sorted() error; expected: %v, got: %v", expected, sorted)
+		}
+	}
+}
+
+func TestTopK_Offer(t *testing.T) {
+	tk := gost.NewTopK(3)
+	for i, priority := range []float64{5, 1, 3} {
+		if accepted, evicted := tk.Offer(i, priority); !accepted || evicted != nil {
+			t.Errorf("Offer() error; expected item %v to be accepted without eviction", i)
+		}
+	}
+	accepted, evicted := tk.Offer("low", 0)
+	if accepted || evicted != "low" {
+		t.Errorf("Offer() error; expected low priority item to be rejected, got: %v, %v", accepted, evicted)
+	}
+	accepted, evicted = tk.Offer("high", 10)
+	if !accepted || evicted != 1 {
+		t.Errorf("Offer() error; expected item 1 to be evicted, got: %v, %v", accepted, evicted)
+	}
+	if tk.Size() != 3 {
+		t.Errorf("Size() error; expected: %v, got: %v", 3, tk.Size())
+	}
+	assertSorted(t, tk, []interface{}{"high", 0, 2})
+}
+
+func TestTopK_FIFO(t *testing.T) {
+	tk := gost.NewTopK(2)
+	tk.Offer("a", 1)
+	tk.Offer("b", 1)
+	if accepted, _ := tk.Offer("c", 1); accepted {
+		t.Error("Offer() error; later item with equal priority should not evict earlier ones")
+	}
+	assertSorted(t, tk, []interface{}{"a", "b"})
+
+	tk = gost.NewMinTopK(2)
+	tk.Offer("a", 1)
+	tk.Offer("b", 2)
+	tk.Offer("c", 1)
+	assertSorted(t, tk, []interface{}{"a", "c"})
+}
+
+func TestTopK_Random(t *testing.T) {
+	const k = 10
+	random := rand.New(rand.NewSource(42))
+	highest, lowest := gost.NewTopK(k), gost.NewMinTopK(k)
+	priorities := make([]float64, num)
+	for i := range priorities {
+		priorities[i] = float64(random.Intn(num / 10))
+		highest.Offer(i, priorities[i])
+		lowest.Offer(i, priorities[i])
+	}
+	indices := make([]int, num)
+	for i := range indices {
+		indices[i] = i
+	}
+	sort.SliceStable(indices, func(i, j int) bool { return priorities[indices[i]] > priorities[indices[j]] })
+	expected := make([]interface{}, k)
+	for i := range expected {
+		expected[i] = indices[i]
+	}
+	assertSorted(t, highest, expected)
+	sort.SliceStable(indices, func(i, j int) bool { return priorities[indices[i]] < priorities[indices[j]] })
+	for i := range expected {
+		expected[i] = indices[i]
+	}
+	assertSorted(t, lowest, expected)
+}
+
+func TestTopK_Merge(t *testing.T) {
+	a, b, c := gost.NewTopK(3), gost.NewTopK(3), gost.NewTopK(3)
+	a.Offer("a1", 1)
+	a.Offer("a9", 9)
+	b.Offer("b5", 5)
+	b.Offer("b7", 7)
+	b.Offer("b2", 2)
+	c.Offer("c8", 8)
+	if err := a.Merge(b, c); err != nil {
+		t.Fatalf("Merge() failed unexpectedly: %v", err)
+	}
+	assertSorted(t, a, []interface{}{"a9", "c8", "b7"})
+	if b.Size() != 3 || c.Size() != 1 {
+		t.Error("Merge() error; merged instances should be left untouched")
+	}
+}
+
+func TestTopK_MergeItself(t *testing.T) {
+	tk := gost.NewTopK(5)
+	tk.Offer("a", 2)
+	tk.Offer("b", 1)
+	if err := tk.Merge(tk); err != nil {
+		t.Fatalf("Merge() failed unexpectedly: %v", err)
+	}
+	assertSorted(t, tk, []interface{}{"a", "b"})
+}
+
+func TestTopK_MergeOppositeDirection(t *testing.T) {
+	highest, lowest := gost.NewTopK(3), gost.NewMinTopK(3)
+	highest.Offer("h", 5)
+	lowest.Offer("l1", 1)
+	lowest.Offer("l9", 9)
+	if err := highest.Merge(lowest); err == nil {
+		t.Fatal("Merge() did not return error on TopK ranking in the opposite direction")
+	}
+	assertSorted(t, highest, []interface{}{"h"})
+}
@@ -0,0 +1,190 @@
+package gost_test
+
+import (
+	"math/rand"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/christat/gost/tree"
+)
+
+// test helper function; returns a TreeMap mapping random even keys to their double, along with the sorted keys.
+func generateTreeMap(size int) (*gost.TreeMap, []int) {
+	tree := gost.NewTreeMap(compareInts)
+	keys := make([]int, 0, size)
+	for len(keys) < size {
+		key := 2 * rand.Intn(10*size)
+		if !tree.Contains(key) {
+			keys = append(keys, key)
+		}
+		tree.Put(key, 2*key)
+	}
+	sort.Ints(keys)
+	return tree, keys
+}
+
+// test helper function; checks that iterating it yields exactly the expected keys, with their double as values.
+func assertTreeKeys(t *testing.T, it *gost.TreeIterator, expected []int) {
+	t.Helper()
+	for _, key := range expected {
+		if !it.Next() || it.Key() != key || it.Value() != 2*key {
+			t.Fatalf("Next() error; expected key: %v, got: %v", key, it.Key())
+		}
+	}
+	if it.Next() {
+		t.Fatalf("Next() error; expected end of iteration, got: %v", it.Key())
+	}
+}
+
+func TestTreeMap_PutGetDelete(t *testing.T) {
+	tree, keys := generateTreeMap(num)
+	if tree.Size() != num {
+		t.Fatalf("Put() error; expected size: %v, got: %v", num, tree.Size())
+	}
+	assertTreeKeys(t, tree.Iterator(), keys)
+	if _, err := tree.Get(1); err == nil {
+		t.Error("Get() did not return error on missing key")
+	}
+	rand.Shuffle(len(keys), func(i, j int) { keys[i], keys[j] = keys[j], keys[i] })
+	for _, key := range keys[:num/2] {
+		if value, err := tree.Delete(key); err != nil || value != 2*key {
+			t.Fatalf("Delete() failed: returned: %v, expected: %v", value, 2*key)
+		}
+	}
+	if _, err := tree.Delete(keys[0]); err == nil {
+		t.Error("Delete() did not return error on missing key")
+	}
+	rest := keys[num/2:]
+	sort.Ints(rest)
+	assertTreeKeys(t, tree.Iterator(), rest)
+	for _, key := range rest {
+		if value, err := tree.Get(key); err != nil || value != 2*key {
+			t.Fatalf("Get() failed: returned: %v, expected: %v", value, 2*key)
+		}
+	}
+}
+
+func TestTreeMap_OrderQueries(t *testing.T) {
+	empty := gost.NewTreeMap(compareInts)
+	if _, _, err := empty.Min(); err == nil {
+		t.Error("Min() did not return error on empty map")
+	}
+	if _, _, err := empty.Max(); err == nil {
+		t.Error("Max() did not return error on empty map")
+	}
+	tree, keys := generateTreeMap(num)
+	if min, _, _ := tree.Min(); min != keys[0] {
+		t.Errorf("Min() failed: returned: %v, expected: %v", min, keys[0])
+	}
+	if max, _, _ := tree.Max(); max != keys[num-1] {
+		t.Errorf("Max() failed: returned: %v, expected: %v", max, keys[num-1])
+	}
+	for i := 0; i < num; i++ {
+		key := rand.Intn(20*num+2) - 1
+		index := sort.SearchInts(keys, key)
+		if rank := tree.Rank(key); rank != index {
+			t.Fatalf("Rank() error; expected: %v for key %v, got: %v", index, key, rank)
+		}
+		ceiling, _, err := tree.Ceiling(key)
+		if index == len(keys) && err == nil || index < len(keys) && ceiling != keys[index] {
+			t.Fatalf("Ceiling() error; expected key %v to be found, got: %v (%v)", key, ceiling, err)
+		}
+		floorIndex := index - 1
+		if index < len(keys) && keys[index] == key {
+			floorIndex = index
+		}
+		floor, _, err := tree.Floor(key)
+		if floorIndex < 0 && err == nil || floorIndex >= 0 && floor != keys[floorIndex] {
+			t.Fatalf("Floor() error; expected key %v to be found, got: %v (%v)", key, floor, err)
+		}
+	}
+	for i, key := range keys {
+		if selected, value, err := tree.Select(i); err != nil || selected != key || value != 2*key {
+			t.Fatalf("Select() failed: returned: %v, expected: %v", selected, key)
+		}
+	}
+	if last, _, _ := tree.Select(-1); last != keys[num-1] {
+		t.Errorf("Select() failed: returned: %v, expected: %v", last, keys[num-1])
+	}
+	if _, _, err := tree.Select(num); err == nil {
+		t.Error("Select() did not return error on exceeding size index")
+	}
+}
+
+func TestTreeMap_Ranges(t *testing.T) {
+	tree, keys := generateTreeMap(100)
+	assertTreeKeys(t, tree.Range(keys[10], keys[20]), keys[10:20])
+	assertTreeKeys(t, tree.Range(keys[10]-1, keys[20]+1), keys[10:21])
+	assertTreeKeys(t, tree.Range(nil, keys[5]), keys[:5])
+	assertTreeKeys(t, tree.Range(keys[95], nil), keys[95:])
+	if removed := tree.DeleteRange(keys[10], keys[20]); removed != 10 {
+		t.Fatalf("DeleteRange() error; expected %v keys removed, got: %v", 10, removed)
+	}
+	assertTreeKeys(t, tree.Iterator(), append(append([]int{}, keys[:10]...), keys[20:]...))
+	if removed := tree.DeleteRange(nil, nil); removed != 90 || tree.Size() != 0 {
+		t.Errorf("DeleteRange() error; expected the map to be emptied, got size: %v", tree.Size())
+	}
+}
+
+func TestTreeSet(t *testing.T) {
+	set := gost.NewTreeSet(compareInts)
+	for _, member := range []int{5, 1, 9, 5, 3} {
+		set.Add(member)
+	}
+	if set.Size() != 4 || !set.Contains(9) || set.Contains(2) {
+		t.Fatalf("Add() error; expected 4 distinct members, got: %v", set.Size())
+	}
+	if floor, _ := set.Floor(4); floor != 3 {
+		t.Errorf("Floor() failed: returned: %v, expected: %v", floor, 3)
+	}
+	if ceiling, _ := set.Ceiling(6); ceiling != 9 {
+		t.Errorf("Ceiling() failed: returned: %v, expected: %v", ceiling, 9)
+	}
+	if err := set.Remove(2); err == nil {
+		t.Error("Remove() did not return error on missing member")
+	}
+	set.Remove(5)
+	if second, _ := set.Select(1); second != 3 || set.Rank(9) != 2 {
+		t.Errorf("Select() failed: returned: %v, expected: %v", second, 3)
+	}
+	for i, it := 0, set.Iterator(); it.Next(); i++ {
+		if expected := []int{1, 3, 9}[i]; it.Key() != expected || it.Value() != expected {
+			t.Fatalf("Iterator() error; expected member: %v, got: %v", expected, it.Key())
+		}
+	}
+}
+
+func TestTreeSet_AddEqualMember(t *testing.T) {
+	set := gost.NewTreeSet(func(a, b interface{}) int {
+		return strings.Compare(strings.ToLower(a.(string)), strings.ToLower(b.(string)))
+	})
+	set.Add("Go")
+	set.Add("GO")
+	if set.Size() != 1 {
+		t.Fatalf("Add() error; expected a single member, got: %v", set.Size())
+	}
+	it := set.Iterator()
+	if !it.Next() || it.Key() != "Go" || it.Value() != "Go" {
+		t.Errorf("Add() error; expected the member first added to be kept, got key: %v and value: %v", it.Key(), it.Value())
+	}
+}
+
+/*
+TreeMap Benchmark: measure insertions and lookups of random keys, comparable with the SkipList ones
+*/
+
+func BenchmarkTreeMap_Put(b *testing.B) {
+	tree := gost.NewTreeMap(compareInts)
+	for i := 0; i < b.N; i++ {
+		tree.Put(rand.Intn(bigNum), i)
+	}
+}
+
+func BenchmarkTreeMap_Get(b *testing.B) {
+	tree, _ := generateTreeMap(bigNum / 10)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		tree.Get(rand.Intn(2 * bigNum))
+	}
+}
@@ -0,0 +1,392 @@
+// Package gost is a (minimal) data structures library for Go.
+// This package implements ordered maps and sets backed by balanced binary search trees.
+package gost
+
+import (
+	"errors"
+
+	"github.com/christat/gost/stack"
+)
+
+/*
+TreeMap is an ordered map backed by an AVL tree whose nodes keep track of the size of their subtrees. Keys may be
+of any type, ordered by a compare function returning a negative number, zero or a positive number when a is
+respectively lower than, equal to or greater than b. It allows:
+
+- Putting/Getting/Deleting: storing, retrieving and removing the value of a key in O(log n).
+
+- Searching by order: obtaining the entries with the lowest (Min) and greatest (Max) keys, the greatest key lower
+or equal to a given one (Floor), or the lowest key greater or equal to it (Ceiling), in O(log n).
+
+- Ranking/Selecting: obtaining how many keys are lower than a given one, or the entry at any given position in
+ascending order of keys, in O(log n).
+
+- Scanning: iterating over the entries within a range of keys in ascending order, or deleting them all.
+
+Note that the implementation is NOT thread-safe.
+*/
+type TreeMap struct {
+	compare func(a, b interface{}) int
+	root    *treeNode
+}
+
+// treeNode holds an entry of a TreeMap, along with the height and size of the subtree rooted at it.
+type treeNode struct {
+	key    interface{}
+	value  interface{}
+	left   *treeNode
+	right  *treeNode
+	height int
+	size   int
+}
+
+// NewTreeMap creates an empty TreeMap ordering keys by compare.
+func NewTreeMap(compare func(a, b interface{}) int) *TreeMap {
+	return &TreeMap{compare: compare}
+}
+
+// Put stores value under key, replacing the previous value of key if already present.
+func (tm *TreeMap) Put(key, value interface{}) {
+	tm.root = tm.put(tm.root, key, value)
+}
+
+// Get obtains the value stored under key. Returns the value or an error if key is not present.
+func (tm *TreeMap) Get(key interface{}) (interface{}, error) {
+	node := tm.find(key)
+	if node == nil {
+		return nil, errors.New("cannot Get() key not found")
+	}
+	return node.value, nil
+}
+
+// Contains responds whether key is present.
+func (tm *TreeMap) Contains(key interface{}) bool {
+	return tm.find(key) != nil
+}
+
+// Delete removes key, returning its value. Returns an error if key is not present.
+func (tm *TreeMap) Delete(key interface{}) (interface{}, error) {
+	node := tm.find(key)
+	if node == nil {
+		return nil, errors.New("cannot Delete() key not found")
+	}
+	tm.root = tm.delete(tm.root, key)
+	return node.value, nil
+}
+
+// DeleteRange removes every key in [from, to), returning how many were removed.
+// A nil from or to leaves the range unbounded on that side.
+func (tm *TreeMap) DeleteRange(from, to interface{}) int {
+	var keys []interface{}
+	for it := tm.Range(from, to); it.Next(); {
+		keys = append(keys, it.Key())
+	}
+	for _, key := range keys {
+		tm.root = tm.delete(tm.root, key)
+	}
+	return len(keys)
+}
+
+// Min obtains the entry with the lowest key. Returns an error if the map is empty.
+func (tm *TreeMap) Min() (interface{}, interface{}, error) {
+	if tm.root == nil {
+		return nil, nil, errors.New("cannot Min() empty map")
+	}
+	node := tm.root
+	for node.left != nil {
+		node = node.left
+	}
+	return node.key, node.value, nil
+}
+
+// Max obtains the entry with the greatest key. Returns an error if the map is empty.
+func (tm *TreeMap) Max() (interface{}, interface{}, error) {
+	if tm.root == nil {
+		return nil, nil, errors.New("cannot Max() empty map")
+	}
+	node := tm.root
+	for node.right != nil {
+		node = node.right
+	}
+	return node.key, node.value, nil
+}
+
+// Floor obtains the entry with the greatest key lower or equal to key. Returns an error if there is none.
+func (tm *TreeMap) Floor(key interface{}) (interface{}, interface{}, error) {
+	var floor *treeNode
+	for node := tm.root; node != nil; {
+		if cmp := tm.compare(key, node.key); cmp < 0 {
+			node = node.left
+		} else {
+			floor = node
+			if cmp == 0 {
+				break
+			}
+			node = node.right
+		}
+	}
+	if floor == nil {
+		return nil, nil, errors.New("cannot Floor() no lower or equal key")
+	}
+	return floor.key, floor.value, nil
+}
+
+// Ceiling obtains the entry with the lowest key greater or equal to key. Returns an error if there is none.
+func (tm *TreeMap) Ceiling(key interface{}) (interface{}, interface{}, error) {
+	var ceiling *treeNode
+	for node := tm.root; node != nil; {
+		if cmp := tm.compare(key, node.key); cmp > 0 {
+			node = node.right
+		} else {
+			ceiling = node
+			if cmp == 0 {
+				break
+			}
+			node = node.left
+		}
+	}
+	if ceiling == nil {
+		return nil, nil, errors.New("cannot Ceiling() no greater or equal key")
+	}
+	return ceiling.key, ceiling.value, nil
+}
+
+// Rank returns the amount of keys lower than key, which is the position key has (or would have) in ascending order.
+func (tm *TreeMap) Rank(key interface{}) int {
+	rank := 0
+	for node := tm.root; node != nil; {
+		if cmp := tm.compare(key, node.key); cmp <= 0 {
+			if cmp == 0 {
+				return rank + sizeOf(node.left)
+			}
+			node = node.left
+		} else {
+			rank += sizeOf(node.left) + 1
+			node = node.right
+		}
+	}
+	return rank
+}
+
+// Select obtains the entry at position index in ascending order of keys; negative indexes count from the greatest
+// key backwards. Returns the entry or an error if out of bounds.
+func (tm *TreeMap) Select(index int) (interface{}, interface{}, error) {
+	if index < 0 {
+		index += tm.Size()
+	}
+	if index >= tm.Size() || index < 0 {
+		return nil, nil, errors.New("cannot Select() index out of bounds")
+	}
+	node := tm.root
+	for {
+		if leftSize := sizeOf(node.left); index < leftSize {
+			node = node.left
+		} else if index > leftSize {
+			index -= leftSize + 1
+			node = node.right
+		} else {
+			return node.key, node.value, nil
+		}
+	}
+}
+
+// Range returns an iterator over the entries with keys in [from, to), in ascending order.
+// A nil from or to leaves the range unbounded on that side.
+func (tm *TreeMap) Range(from, to interface{}) *TreeIterator {
+	it := &TreeIterator{compare: tm.compare, to: to}
+	for node := tm.root; node != nil; {
+		if from != nil && tm.compare(node.key, from) < 0 {
+			node = node.right
+		} else {
+			it.pending.Push(node)
+			node = node.left
+		}
+	}
+	return it
+}
+
+// Iterator returns an iterator over every entry, in ascending order of keys.
+func (tm *TreeMap) Iterator() *TreeIterator {
+	return tm.Range(nil, nil)
+}
+
+// Size returns the amount of keys in the TreeMap.
+func (tm *TreeMap) Size() int {
+	return sizeOf(tm.root)
+}
+
+// Internal function returning the node holding key, or nil if not present.
+func (tm *TreeMap) find(key interface{}) *treeNode {
+	node := tm.root
+	for node != nil {
+		cmp := tm.compare(key, node.key)
+		if cmp == 0 {
+			return node
+		}
+		if cmp < 0 {
+			node = node.left
+		} else {
+			node = node.right
+		}
+	}
+	return nil
+}
+
+// Internal function which stores value under key in the subtree rooted at node, returning its new root.
+func (tm *TreeMap) put(node *treeNode, key, value interface{}) *treeNode {
+	if node == nil {
+		return &treeNode{key: key, value: value, height: 1, size: 1}
+	}
+	switch cmp := tm.compare(key, node.key); {
+	case cmp < 0:
+		node.left = tm.put(node.left, key, value)
+	case cmp > 0:
+		node.right = tm.put(node.right, key, value)
+	default:
+		node.value = value
+		return node
+	}
+	return rebalance(node)
+}
+
+// Internal function which removes key (which must be present) from the subtree rooted at node, returning its new root.
+func (tm *TreeMap) delete(node *treeNode, key interface{}) *treeNode {
+	switch cmp := tm.compare(key, node.key); {
+	case cmp < 0:
+		node.left = tm.delete(node.left, key)
+	case cmp > 0:
+		node.right = tm.delete(node.right, key)
+	default:
+		if node.left == nil {
+			return node.right
+		}
+		if node.right == nil {
+			return node.left
+		}
+		// Replace the node by its successor, the lowest node of its right subtree.
+		var successor *treeNode
+		node.right, successor = detachMin(node.right)
+		successor.left, successor.right = node.left, node.right
+		node = successor
+	}
+	return rebalance(node)
+}
+
+// Internal function which removes the lowest node of the subtree rooted at node. Returns the new root of the
+// subtree and the removed node.
+func detachMin(node *treeNode) (*treeNode, *treeNode) {
+	if node.left == nil {
+		return node.right, node
+	}
+	var min *treeNode
+	node.left, min = detachMin(node.left)
+	return rebalance(node), min
+}
+
+// Internal function which restores the AVL balance of node, whose subtrees are balanced and differ in height by
+// at most two, updating heights and sizes. Returns the new root of the subtree.
+func rebalance(node *treeNode) *treeNode {
+	update(node)
+	switch balance := heightOf(node.left) - heightOf(node.right); {
+	case balance > 1:
+		if heightOf(node.left.left) < heightOf(node.left.right) {
+			node.left = rotateLeft(node.left)
+		}
+		return rotateRight(node)
+	case balance < -1:
+		if heightOf(node.right.right) < heightOf(node.right.left) {
+			node.right = rotateRight(node.right)
+		}
+		return rotateLeft(node)
+	}
+	return node
+}
+
+// Internal function which rotates the subtree rooted at node to the left, returning its new root.
+func rotateLeft(node *treeNode) *treeNode {
+	root := node.right
+	node.right, root.left = root.left, node
+	update(node)
+	update(root)
+	return root
+}
+
+// Internal function which rotates the subtree rooted at node to the right, returning its new root.
+func rotateRight(node *treeNode) *treeNode {
+	root := node.left
+	node.left, root.right = root.right, node
+	update(node)
+	update(root)
+	return root
+}
+
+// Internal function which recomputes the height and size of node from those of its children.
+func update(node *treeNode) {
+	node.height = heightOf(node.left) + 1
+	if right := heightOf(node.right); right >= node.height {
+		node.height = right + 1
+	}
+	node.size = sizeOf(node.left) + sizeOf(node.right) + 1
+}
+
+// Internal function returning the height of node, zero for nil nodes.
+func heightOf(node *treeNode) int {
+	if node == nil {
+		return 0
+	}
+	return node.height
+}
+
+// Internal function returning the size of the subtree rooted at node, zero for nil nodes.
+func sizeOf(node *treeNode) int {
+	if node == nil {
+		return 0
+	}
+	return node.size
+}
+
+/*
+TreeIterator walks a range of entries of a TreeMap (or TreeSet) in ascending order of keys. It implements the
+Iterator interface of the list package, yielding the values of the entries, so it can be used with its functional
+helpers. The tree must not be modified while iterating it.
+*/
+type TreeIterator struct {
+	compare func(a, b interface{}) int
+	pending gost.SliceStack // nodes whose key and right subtree are yet to be visited
+	current *treeNode
+	to      interface{}
+}
+
+// Next advances to the next entry of the range. Returns false once past the last one.
+func (it *TreeIterator) Next() bool {
+	it.current = nil
+	if it.pending.Size() == 0 {
+		return false
+	}
+	node := it.pending.Pop().(*treeNode)
+	if it.to != nil && it.compare(node.key, it.to) >= 0 {
+		it.pending = gost.SliceStack{}
+		return false
+	}
+	for child := node.right; child != nil; child = child.left {
+		it.pending.Push(child)
+	}
+	it.current = node
+	return true
+}
+
+// Key returns the key of the entry reached, or nil if none.
+func (it *TreeIterator) Key() interface{} {
+	if it.current == nil {
+		return nil
+	}
+	return it.current.key
+}
+
+// Value returns the value of the entry reached, or nil if none.
+func (it *TreeIterator) Value() interface{} {
+	if it.current == nil {
+		return nil
+	}
+	return it.current.value
+}
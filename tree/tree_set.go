@@ -0,0 +1,98 @@
+package gost
+
+import "errors"
+
+/*
+TreeSet is an ordered set backed by a TreeMap, whose keys are the members of the set. It allows the same ordered
+queries as TreeMap (Min, Max, Floor, Ceiling, Rank and Select, in O(log n)), returning members alone. Its iterators
+yield the members in ascending order, both as keys and as values.
+
+Note that the implementation is NOT thread-safe.
+*/
+type TreeSet struct {
+	tree *TreeMap
+}
+
+// NewTreeSet creates an empty TreeSet ordering members by compare (see TreeMap).
+func NewTreeSet(compare func(a, b interface{}) int) *TreeSet {
+	return &TreeSet{tree: NewTreeMap(compare)}
+}
+
+// Add inserts member into the set, if not present yet. If an equal member is present, the set is left unchanged,
+// keeping the member first added.
+func (ts *TreeSet) Add(member interface{}) {
+	if !ts.tree.Contains(member) {
+		ts.tree.Put(member, member)
+	}
+}
+
+// Remove deletes member from the set. Returns an error if member is not present.
+func (ts *TreeSet) Remove(member interface{}) error {
+	if _, err := ts.tree.Delete(member); err != nil {
+		return errors.New("cannot Remove() member not found")
+	}
+	return nil
+}
+
+// RemoveRange deletes every member in [from, to), returning how many were removed.
+// A nil from or to leaves the range unbounded on that side.
+func (ts *TreeSet) RemoveRange(from, to interface{}) int {
+	return ts.tree.DeleteRange(from, to)
+}
+
+// Contains responds whether member is present.
+func (ts *TreeSet) Contains(member interface{}) bool {
+	return ts.tree.Contains(member)
+}
+
+// Min obtains the lowest member. Returns an error if the set is empty.
+func (ts *TreeSet) Min() (interface{}, error) {
+	member, _, err := ts.tree.Min()
+	return member, err
+}
+
+// Max obtains the greatest member. Returns an error if the set is empty.
+func (ts *TreeSet) Max() (interface{}, error) {
+	member, _, err := ts.tree.Max()
+	return member, err
+}
+
+// Floor obtains the greatest member lower or equal to value. Returns an error if there is none.
+func (ts *TreeSet) Floor(value interface{}) (interface{}, error) {
+	member, _, err := ts.tree.Floor(value)
+	return member, err
+}
+
+// Ceiling obtains the lowest member greater or equal to value. Returns an error if there is none.
+func (ts *TreeSet) Ceiling(value interface{}) (interface{}, error) {
+	member, _, err := ts.tree.Ceiling(value)
+	return member, err
+}
+
+// Rank returns the amount of members lower than value.
+func (ts *TreeSet) Rank(value interface{}) int {
+	return ts.tree.Rank(value)
+}
+
+// Select obtains the member at position index in ascending order; negative indexes count from the greatest member
+// backwards. Returns the member or an error if out of bounds.
+func (ts *TreeSet) Select(index int) (interface{}, error) {
+	member, _, err := ts.tree.Select(index)
+	return member, err
+}
+
+// Range returns an iterator over the members in [from, to), in ascending order.
+// A nil from or to leaves the range unbounded on that side.
+func (ts *TreeSet) Range(from, to interface{}) *TreeIterator {
+	return ts.tree.Range(from, to)
+}
+
+// Iterator returns an iterator over every member, in ascending order.
+func (ts *TreeSet) Iterator() *TreeIterator {
+	return ts.tree.Iterator()
+}
+
+// Size returns the amount of members in the TreeSet.
+func (ts *TreeSet) Size() int {
+	return ts.tree.Size()
+}